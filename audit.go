@@ -0,0 +1,105 @@
+package gopulse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// auditBufferSize is the number of audit lines buffered before new ones are dropped
+const auditBufferSize = 1024
+
+// asyncWriter writes lines to an io.Writer from a background goroutine so a slow
+// writer never stalls the caller. Lines are dropped when the buffer is full.
+type asyncWriter struct {
+	w       io.Writer
+	lines   chan []byte
+	dropped atomic.Int64
+}
+
+// newAsyncWriter creates an asyncWriter buffering up to size lines
+func newAsyncWriter(w io.Writer, size int) *asyncWriter {
+	return &asyncWriter{
+		w:     w,
+		lines: make(chan []byte, size),
+	}
+}
+
+// write queues a line without blocking, dropping it if the buffer is full
+func (a *asyncWriter) write(line []byte) {
+	select {
+	case a.lines <- line:
+	default:
+		a.dropped.Add(1)
+	}
+}
+
+// run writes queued lines until the context is done, then flushes what is still buffered
+func (a *asyncWriter) run(ctx context.Context) {
+	for {
+		select {
+		case line := <-a.lines:
+			_, _ = a.w.Write(line)
+		case <-ctx.Done():
+			for {
+				select {
+				case line := <-a.lines:
+					_, _ = a.w.Write(line)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// auditEvent is a single state transition written to the audit log as a JSON line
+type auditEvent struct {
+	Time     time.Time `json:"time"`
+	Name     string    `json:"name"`
+	From     Status    `json:"from"`
+	To       Status    `json:"to"`
+	Error    string    `json:"error,omitempty"`
+	Duration string    `json:"duration"`
+}
+
+// checkStatus summarizes a check's state: UNKNOWN until first checked, UP when both live
+// and ready, DOWN otherwise
+func checkStatus(status *HealthStatus) Status {
+	switch {
+	case !status.checked:
+		return StatusUnknown
+	case status.Liveness && status.Readiness:
+		return StatusUp
+	default:
+		return StatusDown
+	}
+}
+
+// auditTransition appends an audit event if the check's state changed
+func (ha *HealthAggregator) auditTransition(name string, prev, next *HealthStatus) {
+	from, to := checkStatus(prev), checkStatus(next)
+	if from == to {
+		return
+	}
+
+	event := auditEvent{
+		Time:     next.LastUpdate,
+		Name:     name,
+		From:     from,
+		To:       to,
+		Duration: next.Duration.String(),
+	}
+	if err := errors.Join(next.LivenessErr, next.ReadinessErr); err != nil {
+		event.Error = err.Error()
+	}
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	ha.auditLog.write(append(line, '\n'))
+}
@@ -0,0 +1,105 @@
+package gopulse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestAuditLog(t *testing.T) {
+	ctx := context.Background()
+	var buf syncBuffer
+	ha := NewHealthAggregator(ctx, WithAuditLog(&buf))
+	checker := &mockHealthChecker{name: "db"}
+
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+
+	// Let each update apply, as pending updates of a checker are coalesced
+	ha.UpdateHealth(checker, nil, nil)
+	time.Sleep(20 * time.Millisecond)
+	ha.UpdateHealth(checker, nil, nil)
+	time.Sleep(20 * time.Millisecond)
+	ha.UpdateHealth(checker, nil, errors.New("connection refused"))
+	time.Sleep(100 * time.Millisecond)
+	ha.Stop()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 transitions, got %d: %q", len(lines), lines)
+	}
+
+	var events [2]auditEvent
+	for i, line := range lines {
+		if err := json.Unmarshal([]byte(line), &events[i]); err != nil {
+			t.Fatalf("Expected JSON line, got %q: %v", line, err)
+		}
+	}
+
+	if events[0].Name != "db" || events[0].From != StatusUnknown || events[0].To != StatusUp {
+		t.Errorf("Expected db UNKNOWN -> UP, got %+v", events[0])
+	}
+	if events[1].From != StatusUp || events[1].To != StatusDown || events[1].Error != "connection refused" {
+		t.Errorf("Expected db UP -> DOWN with error, got %+v", events[1])
+	}
+	if events[1].Time.IsZero() {
+		t.Error("Expected event timestamp")
+	}
+}
+
+// blockingWriter blocks every write until released
+type blockingWriter struct {
+	release chan struct{}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.release
+	return len(p), nil
+}
+
+func TestAuditLogDoesNotBlock(t *testing.T) {
+	w := &blockingWriter{release: make(chan struct{})}
+	audit := newAsyncWriter(w, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		audit.run(ctx)
+		close(done)
+	}()
+
+	// Writing far more lines than the buffer holds must not block
+	for i := 0; i < 100; i++ {
+		audit.write([]byte("line\n"))
+	}
+	if audit.dropped.Load() == 0 {
+		t.Error("Expected lines to be dropped while the writer is stalled")
+	}
+
+	close(w.release)
+	cancel()
+	<-done
+}
@@ -0,0 +1,32 @@
+package gopulse
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// BuildInfo identifies the build a process is running, to correlate health with a rollout
+type BuildInfo struct {
+	GoVersion string `json:"goVersion"`
+	Path      string `json:"path,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Revision  string `json:"revision,omitempty"`
+}
+
+// readBuildInfo collects the Go runtime version and the main module's version and VCS revision
+func readBuildInfo() *BuildInfo {
+	info := &BuildInfo{GoVersion: runtime.Version()}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	info.Path = build.Main.Path
+	info.Version = build.Main.Version
+	for _, setting := range build.Settings {
+		if setting.Key == "vcs.revision" {
+			info.Revision = setting.Value
+		}
+	}
+	return info
+}
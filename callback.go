@@ -0,0 +1,40 @@
+package gopulse
+
+import "time"
+
+// runCallback runs a user callback on the update goroutine, recovering and logging a panic so
+// it can't take down update processing. With a CallbackTimeout, the callback runs on its own
+// goroutine and is abandoned (left running, with a warning) once it exceeds the timeout, so a
+// blocked callback can't stall updates.
+func (ha *HealthAggregator) runCallback(callback string, fn func()) {
+	timeout := ha.config.CallbackTimeout
+	if timeout <= 0 {
+		ha.recoverCallback(callback, fn)
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ha.recoverCallback(callback, fn)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+		ha.logger().Warn("health callback exceeded its timeout and was left running",
+			"callback", callback, "timeout", timeout)
+	}
+}
+
+// recoverCallback calls fn, logging instead of propagating a panic
+func (ha *HealthAggregator) recoverCallback(callback string, fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			ha.logger().Error("health callback panicked", "callback", callback, "panic", r)
+		}
+	}()
+	fn()
+}
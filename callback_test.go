@@ -0,0 +1,171 @@
+package gopulse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPanickingCallbackIsRecovered(t *testing.T) {
+	ctx := context.Background()
+	var logs syncBuffer
+	ha := NewHealthAggregator(ctx,
+		WithStatusChangeCallback(func(name string, status *HealthStatus) {
+			panic("callback bug")
+		}),
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+	)
+	checker := &mockHealthChecker{name: "test"}
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(checker, nil, nil)
+	time.Sleep(50 * time.Millisecond)
+	if !strings.Contains(logs.String(), "health callback panicked") {
+		t.Errorf("Expected the panic to be logged, got %q", logs.String())
+	}
+
+	// Updates keep being processed
+	ha.UpdateHealth(checker, errors.New("down"), nil)
+	time.Sleep(50 * time.Millisecond)
+	if live, _ := ha.GetLiveness(); live {
+		t.Error("Expected updates to be applied after a callback panicked")
+	}
+}
+
+func TestBlockingCallbackTimesOut(t *testing.T) {
+	ctx := context.Background()
+	var logs syncBuffer
+	release := make(chan struct{})
+	defer close(release)
+	ha := NewHealthAggregator(ctx,
+		WithStatusChangeCallback(func(name string, status *HealthStatus) {
+			<-release
+		}),
+		WithCallbackTimeout(20*time.Millisecond),
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+	)
+	checker := &mockHealthChecker{name: "test"}
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(checker, nil, nil)
+	time.Sleep(50 * time.Millisecond)
+	ha.UpdateHealth(checker, errors.New("down"), nil)
+	time.Sleep(50 * time.Millisecond)
+
+	if live, _ := ha.GetLiveness(); live {
+		t.Error("Expected a blocked callback not to stall updates")
+	}
+	if !strings.Contains(logs.String(), "exceeded its timeout") {
+		t.Errorf("Expected the timeout to be logged, got %q", logs.String())
+	}
+}
+
+func TestResultObservers(t *testing.T) {
+	ctx := context.Background()
+	var first, second syncBuffer
+	ha := NewHealthAggregator(ctx,
+		WithResultObserver(func(name string, status *HealthStatus) {
+			first.Write([]byte(name + "\n"))
+		}),
+		WithResultObserver(func(name string, status *HealthStatus) {
+			if status == nil {
+				name += " removed"
+			}
+			second.Write([]byte(name + "\n"))
+		}),
+	)
+	checker := &mockHealthChecker{name: "db"}
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	// Unchanged results are observed too
+	ha.UpdateHealth(checker, nil, nil)
+	time.Sleep(20 * time.Millisecond)
+	ha.UpdateHealth(checker, nil, nil)
+	time.Sleep(50 * time.Millisecond)
+
+	for _, buf := range []*syncBuffer{&first, &second} {
+		if got := buf.String(); got != "db\ndb\n" {
+			t.Errorf("Expected every observer to see both results, got %q", got)
+		}
+	}
+
+	ha.UnregisterHealthCheck("db")
+	ha.UnregisterHealthCheck("db")
+	if got := second.String(); got != "db\ndb\ndb removed\n" {
+		t.Errorf("Expected the removal to be observed once, got %q", got)
+	}
+}
+
+func TestCheckHooks(t *testing.T) {
+	ctx := context.Background()
+	var calls syncBuffer
+	var measured time.Duration
+	ha := NewHealthAggregator(ctx,
+		WithBeforeCheck(func(name string) {
+			calls.Write([]byte("before " + name + "\n"))
+		}),
+		WithAfterCheck(func(name string, liveness, readiness error, d time.Duration) {
+			fmt.Fprintf(&calls, "after %s %v %v\n", name, liveness, readiness)
+			measured = d
+		}),
+	)
+	checker := &slowHealthChecker{
+		mockHealthChecker: mockHealthChecker{name: "db", readinessErr: errors.New("timeout")},
+		delay:             10 * time.Millisecond,
+	}
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	// Hooks fire on every execution, even with an unchanged result, but not on checks skipped
+	// for backoff
+	ha.checkHealth(checker)
+	ha.checkHealth(checker)
+	ha.ResetStatus("db")
+	ha.checkHealth(checker)
+
+	want := "before db\nafter db <nil> timeout\nbefore db\nafter db <nil> timeout\n"
+	if got := calls.String(); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if measured < 20*time.Millisecond {
+		t.Errorf("Expected the check duration, got %v", measured)
+	}
+}
+
+func TestCheckHooksSeeRawResults(t *testing.T) {
+	var calls syncBuffer
+	ha := NewHealthAggregator(context.Background(),
+		WithBeforeCheck(func(name string) {
+			calls.Write([]byte("before " + name + "\n"))
+		}),
+		WithAfterCheck(func(name string, liveness, readiness error, d time.Duration) {
+			fmt.Fprintf(&calls, "after %s %v\n", name, readiness)
+		}),
+		WithResultInterceptor(func(name string, livenessErr, readinessErr error) (error, error) {
+			return livenessErr, nil
+		}),
+	)
+	checker := &mockHealthChecker{name: "db", readinessErr: errors.New("timeout")}
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	// On-demand checks fire the hooks too, with the result before interception
+	ha.GetReadinessContext(context.Background())
+
+	want := "before db\nafter db timeout\n"
+	if got := calls.String(); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,78 @@
+package gopulse
+
+import (
+	"fmt"
+	"time"
+)
+
+// CircuitState is the auto-update circuit breaker state of a checker, making its backoff
+// legible: a failing checker opens its circuit and is skipped until the next probe, which
+// runs half-open and closes the circuit again on success
+type CircuitState int
+
+const (
+	// CircuitClosed checkers run on every scheduled check
+	CircuitClosed CircuitState = iota
+	// CircuitOpen checkers are backing off after a failure until HealthStatus.NextProbe
+	CircuitOpen
+	// CircuitHalfOpen checkers are running their trial probe after backing off
+	CircuitHalfOpen
+)
+
+// String returns "closed", "open" or "half-open"
+func (c CircuitState) String() string {
+	switch c {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("circuit %d", int(c))
+	}
+}
+
+// circuitSummary describes a status's circuit for operators, e.g. "open, next probe in 12s",
+// or "" while closed
+func circuitSummary(status *HealthStatus, now time.Time) string {
+	switch status.CircuitState {
+	case CircuitClosed:
+		return ""
+	case CircuitOpen:
+		wait := max(status.NextProbe.Sub(now), 0).Round(time.Second)
+		return fmt.Sprintf("open, next probe in %s", wait)
+	default:
+		return status.CircuitState.String()
+	}
+}
+
+// InBackoff reports whether auto-update currently skips the named checker after a failure,
+// and the remaining time until its next attempt. It is false for unknown checkers.
+func (ha *HealthAggregator) InBackoff(name string) (bool, time.Duration) {
+	ha.mu.RLock()
+	defer ha.mu.RUnlock()
+	remaining, skip := ha.backoffRemaining(name, ha.config.Clock.Monotonic())
+	if !skip {
+		return false, 0
+	}
+	return true, remaining
+}
+
+// backoffRemaining returns how much of a checker's backoff remains at now, and whether a
+// check is skipped for it. Backoff is rounded to the nearest check interval: a check is
+// skipped only while at least half an interval of it remains, so scheduling jitter doesn't
+// cost a whole extra interval. Must be called with ha.mu held.
+func (ha *HealthAggregator) backoffRemaining(name string, now time.Duration) (time.Duration, bool) {
+	backoff := ha.backoffTimes[name]
+	if probe := ha.config.RecoveryProbeInterval; probe > 0 {
+		// Probe anyway every RecoveryProbeInterval to detect a recovery promptly
+		backoff = min(backoff, probe)
+	}
+	lastAttempt, exists := ha.lastCheckAttempt[name]
+	if backoff <= 0 || !exists {
+		return 0, false
+	}
+	remaining := backoff - (now - lastAttempt)
+	return remaining, remaining >= ha.config.CheckInterval/2
+}
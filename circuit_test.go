@@ -0,0 +1,106 @@
+package gopulse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCircuitState(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{wall: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	ha := NewHealthAggregator(ctx, WithClock(clock), WithBackoff(time.Minute, 2.0))
+	checker := &slowHealthChecker{
+		mockHealthChecker: mockHealthChecker{name: "db", readinessErr: errors.New("connection refused")},
+		delay:             50 * time.Millisecond,
+	}
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	circuit := func() (CircuitState, time.Time) {
+		time.Sleep(50 * time.Millisecond)
+		status := ha.Snapshot()["db"]
+		return status.CircuitState, status.NextProbe
+	}
+
+	if state, _ := circuit(); state != CircuitClosed {
+		t.Errorf("Expected a new check to be closed, got %s", state)
+	}
+
+	// A failure opens the circuit until the backoff has passed
+	ha.checkHealth(checker)
+	state, next := circuit()
+	if want := clock.Now().Add(5 * time.Second); state != CircuitOpen || !next.Equal(want) {
+		t.Errorf("Expected open until %v, got %s until %v", want, state, next)
+	}
+	if ha.checkHealth(checker) != nil {
+		t.Error("Expected the check to be skipped while open")
+	}
+
+	recorder := httptest.NewRecorder()
+	ha.DashboardHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	if body := recorder.Body.String(); !strings.Contains(body, "open, next probe in 5s") {
+		t.Errorf("Expected the dashboard to show the open circuit, got:\n%s", body)
+	}
+
+	// The trial probe runs half-open, and failing again reopens the circuit for longer
+	clock.Advance(5*time.Second, 5*time.Second)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ha.checkHealth(checker)
+	}()
+	if state, _ := circuit(); state != CircuitHalfOpen {
+		t.Errorf("Expected half-open during the trial probe, got %s", state)
+	}
+	<-done
+	state, next = circuit()
+	if want := clock.Now().Add(10 * time.Second); state != CircuitOpen || !next.Equal(want) {
+		t.Errorf("Expected open until %v, got %s until %v", want, state, next)
+	}
+
+	// A successful probe closes it
+	checker.readinessErr = nil
+	clock.Advance(10*time.Second, 10*time.Second)
+	ha.checkHealth(checker)
+	if state, next := circuit(); state != CircuitClosed || !next.IsZero() {
+		t.Errorf("Expected closed after recovering, got %s until %v", state, next)
+	}
+}
+
+func TestInBackoff(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{wall: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	ha := NewHealthAggregator(ctx, WithClock(clock), WithBackoff(time.Minute, 2.0))
+	checker := &mockHealthChecker{name: "db", readinessErr: errors.New("connection refused")}
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	if in, _ := ha.InBackoff("db"); in {
+		t.Error("Expected a new check not to be in backoff")
+	}
+	if in, _ := ha.InBackoff("unknown"); in {
+		t.Error("Expected an unknown check not to be in backoff")
+	}
+
+	ha.checkHealth(checker)
+	if in, remaining := ha.InBackoff("db"); !in || remaining != 5*time.Second {
+		t.Errorf("Expected 5s of backoff, got %v %v", in, remaining)
+	}
+	clock.Advance(2*time.Second, 2*time.Second)
+	if in, remaining := ha.InBackoff("db"); !in || remaining != 3*time.Second {
+		t.Errorf("Expected 3s of backoff left, got %v %v", in, remaining)
+	}
+
+	// Within half an interval of the end (2.5s by default), the next check runs
+	clock.Advance(time.Second, time.Second)
+	if in, _ := ha.InBackoff("db"); in {
+		t.Error("Expected backoff to be over")
+	}
+}
@@ -0,0 +1,36 @@
+package gopulse
+
+import "time"
+
+// Clock provides time to the HealthAggregator. Now is used for reported timestamps such as
+// HealthStatus.LastUpdate, while Monotonic is used for measuring ages (expiry, backoff), so
+// wall clock adjustments like NTP corrections or VM pause/resume can't make checks spuriously
+// expire or un-expire.
+type Clock interface {
+	// Now returns the current wall clock time.
+	Now() time.Time
+
+	// Monotonic returns the time elapsed since an arbitrary fixed point. It must never go backwards.
+	Monotonic() time.Duration
+}
+
+// systemClock is the default Clock backed by the runtime's monotonic clock
+type systemClock struct {
+	start time.Time
+}
+
+// newSystemClock returns a Clock whose monotonic readings start at zero
+func newSystemClock() *systemClock {
+	return &systemClock{start: time.Now()}
+}
+
+// Now returns time.Now()
+func (c *systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Monotonic returns the time elapsed since the clock was created, measured with
+// the monotonic clock reading carried by time.Now()
+func (c *systemClock) Monotonic() time.Duration {
+	return time.Since(c.start)
+}
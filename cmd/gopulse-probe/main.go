@@ -0,0 +1,89 @@
+// Command gopulse-probe queries a gopulse liveness or readiness endpoint and exits 0 when
+// the service reports UP and 1 otherwise, printing the failing checks. It is meant for use
+// as a Docker HEALTHCHECK or Kubernetes exec probe:
+//
+//	HEALTHCHECK CMD ["gopulse-probe", "-url", "http://localhost:8080", "-probe", "readiness"]
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nduyhai/gopulse"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run parses flags, performs the probe and returns the process exit code
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("gopulse-probe", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	baseURL := flags.String("url", "http://localhost:8080", "base URL of the service")
+	probe := flags.String("probe", "readiness", "probe to query: liveness or readiness")
+	timeout := flags.Duration("timeout", 5*time.Second, "request timeout")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *probe != "liveness" && *probe != "readiness" {
+		fmt.Fprintf(stderr, "unknown probe %q, want liveness or readiness\n", *probe)
+		return 2
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	resp, err := fetch(ctx, strings.TrimSuffix(*baseURL, "/")+"/"+*probe)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", *probe, err)
+		return 1
+	}
+
+	fmt.Fprintf(stdout, "%s: %s\n", *probe, resp.Status)
+	if resp.Status == gopulse.StatusUp {
+		return 0
+	}
+	if resp.Reason != "" {
+		fmt.Fprintf(stdout, "reason: %s\n", resp.Reason)
+	}
+	names := make([]string, 0, len(resp.Details))
+	for name := range resp.Details {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(stdout, "  %s: %s\n", name, resp.Details[name])
+	}
+	if resp.Omitted > 0 {
+		fmt.Fprintf(stdout, "  ... %d more\n", resp.Omitted)
+	}
+	return 1
+}
+
+// fetch GETs a probe endpoint and decodes its PulseResponse
+func fetch(ctx context.Context, url string) (*gopulse.PulseResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var pulse gopulse.PulseResponse
+	if err := json.NewDecoder(resp.Body).Decode(&pulse); err != nil {
+		return nil, fmt.Errorf("decoding response (HTTP %d): %w", resp.StatusCode, err)
+	}
+	return &pulse, nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nduyhai/gopulse"
+)
+
+func TestRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/liveness":
+			_ = json.NewEncoder(w).Encode(gopulse.NewUpStatus())
+		case "/readiness":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_ = json.NewEncoder(w).Encode(&gopulse.PulseResponse{
+				Status:  gopulse.StatusDown,
+				Reason:  "1 critical check failing: db (timeout)",
+				Details: map[string]gopulse.Status{"db": gopulse.StatusDown},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-url", server.URL, "-probe", "liveness"}, &stdout, &stderr); code != 0 {
+		t.Errorf("Expected exit 0 for UP liveness, got %d (%s)", code, stderr.String())
+	}
+
+	stdout.Reset()
+	if code := run([]string{"-url", server.URL}, &stdout, &stderr); code != 1 {
+		t.Errorf("Expected exit 1 for DOWN readiness, got %d", code)
+	}
+	if !strings.Contains(stdout.String(), "db: DOWN") {
+		t.Errorf("Expected failing check to be printed, got %q", stdout.String())
+	}
+
+	if code := run([]string{"-probe", "startup"}, &stdout, &stderr); code != 2 {
+		t.Errorf("Expected exit 2 for unknown probe, got %d", code)
+	}
+}
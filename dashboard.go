@@ -0,0 +1,103 @@
+package gopulse
+
+import (
+	"embed"
+	"html/template"
+	"net/http"
+	"time"
+)
+
+//go:embed templates/dashboard.html
+var dashboardFS embed.FS
+
+var dashboardTemplate = template.Must(template.ParseFS(dashboardFS, "templates/dashboard.html"))
+
+// dashboardRefresh is how often the dashboard page reloads itself
+const dashboardRefresh = 5 * time.Second
+
+// dashboardPage is the data rendered by the dashboard template
+type dashboardPage struct {
+	Refresh  int
+	Liveness Status
+	Status   Status
+	Reason   string
+	Checks   []dashboardCheck
+}
+
+// dashboardCheck is one row of the dashboard
+type dashboardCheck struct {
+	Name        string
+	Description string
+	Priority    string
+	Liveness    Status
+	Readiness   Status
+	LastUpdate  time.Time
+	Duration    time.Duration
+	Circuit     string
+	Error       string
+}
+
+// DashboardHandler serves a self-contained HTML page listing every check with its color-coded
+// status, last update, latency and circuit state, reloading itself every few seconds. Error
+// messages, in the checks and in the overall reason, are only shown with WithErrorDetails.
+func (ha *HealthAggregator) DashboardHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = dashboardTemplate.Execute(w, ha.dashboardPage())
+	})
+}
+
+// dashboardPage collects the probe results and auto-update state of every check, in detail order
+func (ha *HealthAggregator) dashboardPage() *dashboardPage {
+	liveness, readiness := ha.LivenessResponse(), ha.ReadinessResponse()
+	page := &dashboardPage{
+		Refresh:  int(dashboardRefresh / time.Second),
+		Liveness: liveness.Status,
+		Status:   readiness.Status,
+		Reason:   readiness.Reason,
+	}
+
+	ha.mu.RLock()
+	defer ha.mu.RUnlock()
+
+	now, wallNow := ha.config.Clock.Monotonic(), ha.config.Clock.Now()
+	for _, name := range ha.sortedNames() {
+		status := ha.statuses[name]
+		check := dashboardCheck{
+			Name:        name,
+			Description: status.Description,
+			Priority:    status.Priority.String(),
+			Liveness:    StatusUp,
+			Readiness:   StatusUp,
+			LastUpdate:  status.LastUpdate,
+			Duration:    status.Duration,
+			Circuit:     circuitSummary(status, wallNow),
+		}
+		if !status.checked {
+			check.LastUpdate = time.Time{}
+		}
+		if status.Inactive {
+			check.Liveness, check.Readiness = StatusInactive, StatusInactive
+		} else {
+			livenessOK, livenessErr := ha.statusLiveness(name, status, now)
+			readinessOK, readinessErr := ha.statusReadiness(name, status, now)
+			if !livenessOK {
+				check.Liveness = StatusDown
+			}
+			if !readinessOK {
+				check.Readiness = StatusDown
+			} else if isDegraded(readinessErr) {
+				check.Readiness = StatusDegraded
+			}
+			if ha.config.ErrorDetails {
+				err := readinessErr
+				if err == nil {
+					err = livenessErr
+				}
+				check.Error = sanitizeError(err)
+			}
+		}
+		page.Checks = append(page.Checks, check)
+	}
+	return page
+}
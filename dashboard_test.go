@@ -0,0 +1,73 @@
+package gopulse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDashboardHandler(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx, WithErrorDetails(true))
+	cache := &mockHealthChecker{name: "cache"}
+	db := &mockHealthChecker{name: "db"}
+	ha.RegisterHealthCheck(cache, PriorityLow)
+	ha.RegisterHealthCheck(db, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(cache, nil, Degraded(errors.New("evicting")))
+	ha.UpdateHealth(db, nil, errors.New("<connection refused>"))
+	time.Sleep(100 * time.Millisecond)
+
+	recorder := httptest.NewRecorder()
+	ha.DashboardHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health/dashboard", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", recorder.Code)
+	}
+	if ct := recorder.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("Expected HTML content type, got %q", ct)
+	}
+	body := recorder.Body.String()
+	for _, want := range []string{
+		`<title>Health: DOWN</title>`,
+		`<td>critical</td>`,
+		`<span class="status DEGRADED">DEGRADED</span>`,
+		`&lt;connection refused&gt;`,
+		`http-equiv="refresh"`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected dashboard to contain %q", want)
+		}
+	}
+	if strings.Index(body, "<td>db</td>") > strings.Index(body, "<td>cache</td>") {
+		t.Error("Expected the critical check first")
+	}
+}
+
+func TestDashboardHidesErrors(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	db := &mockHealthChecker{name: "db"}
+	ha.RegisterHealthCheck(db, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(db, nil, errors.New("dial tcp 10.0.0.5:5432: connection refused"))
+	time.Sleep(100 * time.Millisecond)
+
+	recorder := httptest.NewRecorder()
+	ha.DashboardHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health/dashboard", nil))
+	body := recorder.Body.String()
+	if !strings.Contains(body, "<td>db</td>") {
+		t.Error("Expected the failing check to be listed")
+	}
+	if strings.Contains(body, "10.0.0.5") {
+		t.Error("Expected no error message without WithErrorDetails")
+	}
+}
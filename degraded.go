@@ -0,0 +1,56 @@
+package gopulse
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrDegraded marks a readiness error as degraded rather than failed: the check stays ready,
+// but readiness responses report DEGRADED so observers see the partial degradation
+var ErrDegraded = errors.New("degraded")
+
+// Degraded wraps a readiness error so it degrades the service instead of failing readiness
+func Degraded(err error) error {
+	return fmt.Errorf("%w: %w", ErrDegraded, err)
+}
+
+// isDegraded reports whether a readiness error only degrades the service
+func isDegraded(err error) bool {
+	return errors.Is(err, ErrDegraded)
+}
+
+// degradedChecks returns the checks passing readiness with a degraded error, sorted by name
+func (ha *HealthAggregator) degradedChecks() []checkFailure {
+	ha.mu.RLock()
+	defer ha.mu.RUnlock()
+
+	var degraded []checkFailure
+	now := ha.config.Clock.Monotonic()
+	for name, status := range ha.statuses {
+		if !status.affectsHealth() {
+			continue
+		}
+		if ok, err := ha.statusReadiness(name, status, now); ok && isDegraded(err) {
+			degraded = append(degraded, checkFailure{name: name, priority: status.Priority, err: err})
+		}
+	}
+	sort.Slice(degraded, func(i, j int) bool {
+		return degraded[i].name < degraded[j].name
+	})
+	return degraded
+}
+
+// degrade turns an UP readiness result into a DEGRADED one listing the degraded checks, with
+// their errors when withErrors is set
+func (r *pulseResult) degrade(degraded []checkFailure, withErrors bool) {
+	r.status = StatusDegraded
+	r.checks = degraded
+	r.detail = StatusDegraded
+	checks := make([]string, 0, len(degraded))
+	for _, check := range degraded {
+		checks = append(checks, check.describe(withErrors))
+	}
+	r.reason = "degraded: " + strings.Join(checks, ", ")
+}
@@ -0,0 +1,101 @@
+package gopulse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDegradedReadiness(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		name string
+		opts []Option
+		code int
+	}{
+		{"default", nil, http.StatusOK},
+		{"configured", []Option{WithDegradedStatusCode(http.StatusServiceUnavailable)}, http.StatusServiceUnavailable},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ha := NewHealthAggregator(ctx, tc.opts...)
+			search := &mockHealthChecker{name: "search"}
+			db := &mockHealthChecker{name: "db"}
+			ha.RegisterHealthCheck(search, PriorityLow)
+			ha.RegisterHealthCheck(db, PriorityCritical)
+			ha.Start()
+			defer ha.Stop()
+
+			ha.UpdateHealth(search, nil, Degraded(errors.New("replica lagging")))
+			ha.UpdateHealth(db, nil, nil)
+			time.Sleep(100 * time.Millisecond)
+
+			if ready, errs := ha.GetReadiness(); !ready {
+				t.Errorf("Expected degraded check to keep the service ready, got %v", errs)
+			}
+
+			response := ha.ReadinessResponse()
+			if response.Status != StatusDegraded || response.Details["search"] != StatusDegraded {
+				t.Errorf("Expected DEGRADED response, got %+v", response)
+			}
+			if response.Reason != "degraded: search" {
+				t.Errorf("Unexpected reason %q", response.Reason)
+			}
+
+			rec := httptest.NewRecorder()
+			ha.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readiness", nil))
+			if rec.Code != tc.code {
+				t.Errorf("Expected status code %d, got %d", tc.code, rec.Code)
+			}
+
+			// A real failure takes precedence over degradation
+			ha.UpdateHealth(db, nil, errors.New("connection refused"))
+			time.Sleep(100 * time.Millisecond)
+			if response := ha.ReadinessResponse(); response.Status != StatusDown {
+				t.Errorf("Expected DOWN with a failing check, got %s", response.Status)
+			}
+		})
+	}
+}
+
+func TestSoftDependency(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx, WithExpiryTime(time.Hour))
+	recommendations := &mockHealthChecker{name: "recommendations", readinessErr: errors.New("connection refused")}
+	db := &mockHealthChecker{name: "db"}
+	ha.RegisterHealthCheckWithOptions(recommendations, PriorityHigh, CheckOptions{Soft: true})
+	ha.RegisterHealthCheck(db, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(recommendations, nil, recommendations.readinessErr)
+	ha.UpdateHealth(db, nil, nil)
+	time.Sleep(100 * time.Millisecond)
+
+	if ready, errs := ha.GetReadiness(); !ready {
+		t.Errorf("Expected a failing soft dependency to keep the service ready, got %v", errs)
+	}
+	if ready, errs := ha.GetReadinessContext(ctx); !ready {
+		t.Errorf("Expected fresh results of a soft dependency not to fail readiness, got %v", errs)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	response := ha.ReadinessResponse()
+	if response.Status != StatusDegraded || response.Details["recommendations"] != StatusDegraded {
+		t.Errorf("Expected DEGRADED response, got %+v", response)
+	}
+	for _, check := range ha.DetailedReadinessResponse().Checks {
+		if check.Name == "recommendations" && check.Status != StatusDegraded {
+			t.Errorf("Expected the soft dependency to be listed as DEGRADED, got %s", check.Status)
+		}
+	}
+
+	// Once it recovers, the service is fully up
+	ha.UpdateHealth(recommendations, nil, nil)
+	time.Sleep(100 * time.Millisecond)
+	if response := ha.ReadinessResponse(); response.Status != StatusUp {
+		t.Errorf("Expected UP once the soft dependency recovers, got %+v", response)
+	}
+}
@@ -0,0 +1,162 @@
+package gopulse
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// CheckDetail is the entry of one check in a DetailedPulseResponse
+type CheckDetail struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Status      Status            `json:"status"`
+	Priority    string            `json:"priority"`
+	Tags        []string          `json:"tags,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+	// Error, Kind and Since describe a failing check when WithErrorDetails is enabled
+	Error string    `json:"error,omitempty"`
+	Kind  string    `json:"kind,omitempty"`
+	Since time.Time `json:"since,omitzero"`
+}
+
+// ErrorKinder can be implemented by check errors to classify them in detailed responses,
+// e.g. "auth" or "quota", for automated remediation
+type ErrorKinder interface {
+	Kind() string
+}
+
+// maxErrorLength bounds error messages in detailed responses
+const maxErrorLength = 256
+
+// DetailedPulseResponse lists every registered check with its status for a probe, including
+// healthy and informational ones, for diagnostic endpoints. Checks are in the configured
+// DetailOrder, by priority then name by default.
+type DetailedPulseResponse struct {
+	Status Status        `json:"status"`
+	Reason string        `json:"reason,omitempty"`
+	Checks []CheckDetail `json:"checks"`
+}
+
+// DetailedLivenessResponse builds a DetailedPulseResponse for the liveness probe
+func (ha *HealthAggregator) DetailedLivenessResponse() *DetailedPulseResponse {
+	return ha.newDetailedResponse(ha.statusLiveness, ha.LivenessResponse())
+}
+
+// DetailedReadinessResponse builds a DetailedPulseResponse for the readiness probe
+func (ha *HealthAggregator) DetailedReadinessResponse() *DetailedPulseResponse {
+	return ha.newDetailedResponse(ha.statusReadiness, ha.ReadinessResponse())
+}
+
+// newDetailedResponse lists every check's probe result, in detail order, under the overall
+// status and reason of the probe's summary response. Passing checks reporting a degraded
+// error, such as failing soft dependencies, are listed as DEGRADED.
+func (ha *HealthAggregator) newDetailedResponse(probe probeFunc, summary *PulseResponse) *DetailedPulseResponse {
+	ha.mu.RLock()
+	defer ha.mu.RUnlock()
+
+	checks := make([]CheckDetail, 0, len(ha.statuses))
+	now := ha.config.Clock.Monotonic()
+	for _, name := range ha.sortedNames() {
+		status := ha.statuses[name]
+		detail := CheckDetail{
+			Name:        name,
+			Description: status.Description,
+			Status:      StatusUp,
+			Priority:    status.Priority.String(),
+			Tags:        status.Tags,
+			Details:     status.Details,
+		}
+		if status.Inactive {
+			detail.Status = StatusInactive
+		} else if ok, err := probe(name, status, now); !ok {
+			detail.Status = StatusDown
+			if ha.config.ErrorDetails {
+				detail.Error, detail.Kind, detail.Since = sanitizeError(err), errorKind(err), status.FailingSince
+				if detail.Since.IsZero() {
+					// Expired or never checked: failing since its last update
+					detail.Since = status.LastUpdate
+				}
+			}
+		} else if isDegraded(err) {
+			detail.Status = StatusDegraded
+		}
+		checks = append(checks, detail)
+	}
+
+	return &DetailedPulseResponse{
+		Status: summary.Status,
+		Reason: summary.Reason,
+		Checks: checks,
+	}
+}
+
+// DetailOrder is the order of checks in detailed responses and the dashboard
+type DetailOrder int
+
+const (
+	// OrderByPriority lists the most critical checks first, then by name
+	OrderByPriority DetailOrder = iota
+	// OrderByName lists checks by name only
+	OrderByName
+)
+
+// sortedNames returns the names of the registered checks in the configured detail order.
+// ha.mu must be held.
+func (ha *HealthAggregator) sortedNames() []string {
+	names := slices.Collect(maps.Keys(ha.statuses))
+	sort.Slice(names, func(i, j int) bool {
+		if ha.config.DetailOrder == OrderByPriority {
+			if pi, pj := ha.statuses[names[i]].Priority, ha.statuses[names[j]].Priority; pi != pj {
+				return pi < pj
+			}
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// errorKind classifies a check error: the kind reported by an ErrorKinder, or one of
+// "expired", "stale", "timeout", "canceled", "not_checked" and "error"
+func errorKind(err error) string {
+	var kinder ErrorKinder
+	switch {
+	case err == nil:
+		return "not_checked"
+	case errors.As(err, &kinder):
+		return kinder.Kind()
+	case errors.Is(err, ErrHealthCheckExpired):
+		return "expired"
+	case errors.Is(err, ErrStaleData):
+		return "stale"
+	case errors.Is(err, ErrHealthCheckTimeout), errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, ErrCheckCanceled), errors.Is(err, context.Canceled):
+		return "canceled"
+	default:
+		return "error"
+	}
+}
+
+// sanitizeError returns an error message on a single line without control characters,
+// truncated to maxErrorLength
+func sanitizeError(err error) string {
+	if err == nil {
+		return ""
+	}
+	msg := strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, err.Error())
+	if runes := []rune(msg); len(runes) > maxErrorLength {
+		msg = string(runes[:maxErrorLength]) + "..."
+	}
+	return msg
+}
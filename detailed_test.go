@@ -0,0 +1,178 @@
+package gopulse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// replicaChecker reports replication lag as details
+type replicaChecker struct {
+	mockHealthChecker
+}
+
+func (r *replicaChecker) Details() map[string]string {
+	return map[string]string{"primary": "ok", "replica_lag": "1.2s"}
+}
+
+func TestDetailedReadinessResponse(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	db := &replicaChecker{mockHealthChecker{name: "db"}}
+	cache := &mockHealthChecker{name: "cache"}
+	ha.RegisterHealthCheck(db, PriorityCritical)
+	ha.RegisterHealthCheck(cache, PriorityLow)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(db, nil, nil)
+	ha.UpdateHealth(cache, nil, errors.New("timeout"))
+	time.Sleep(100 * time.Millisecond)
+
+	response := ha.DetailedReadinessResponse()
+	if response.Status != StatusDown || response.Reason == "" {
+		t.Errorf("Expected down with a reason, got %+v", response)
+	}
+	if len(response.Checks) != 2 {
+		t.Fatalf("Expected 2 checks, got %+v", response.Checks)
+	}
+
+	// The critical check comes first
+	dbDetail, cacheDetail := response.Checks[0], response.Checks[1]
+	if cacheDetail.Name != "cache" || cacheDetail.Status != StatusDown || cacheDetail.Priority != "low" || cacheDetail.Details != nil {
+		t.Errorf("Unexpected cache entry %+v", cacheDetail)
+	}
+	if dbDetail.Name != "db" || dbDetail.Status != StatusUp || dbDetail.Priority != "critical" || dbDetail.Details["replica_lag"] != "1.2s" {
+		t.Errorf("Unexpected db entry %+v", dbDetail)
+	}
+}
+
+// quotaError is a check error reporting its kind
+type quotaError struct{}
+
+func (quotaError) Error() string {
+	return "quota exceeded\nretry later"
+}
+
+func (quotaError) Kind() string {
+	return "quota"
+}
+
+func TestErrorDetails(t *testing.T) {
+	ctx := context.Background()
+	for _, enabled := range []bool{false, true} {
+		ha := NewHealthAggregator(ctx, WithErrorDetails(enabled))
+		api := &mockHealthChecker{name: "api"}
+		ha.RegisterHealthCheck(api, PriorityCritical)
+		ha.Start()
+
+		ha.UpdateHealth(api, nil, quotaError{})
+		time.Sleep(50 * time.Millisecond)
+		since := ha.Snapshot()["api"].FailingSince
+		ha.UpdateHealth(api, nil, quotaError{})
+		time.Sleep(50 * time.Millisecond)
+
+		detail := ha.DetailedReadinessResponse().Checks[0]
+		ha.Stop()
+
+		if !enabled {
+			if detail.Error != "" || detail.Kind != "" || !detail.Since.IsZero() {
+				t.Errorf("Expected no error details unless enabled, got %+v", detail)
+			}
+			continue
+		}
+		if detail.Error != "quota exceeded retry later" || detail.Kind != "quota" {
+			t.Errorf("Expected sanitized error and kind, got %+v", detail)
+		}
+		if since.IsZero() || !detail.Since.Equal(since) {
+			t.Errorf("Expected since to stay at the first failure %v, got %v", since, detail.Since)
+		}
+	}
+}
+
+func TestNewDetailedDownStatus(t *testing.T) {
+	response := NewDetailedDownStatus(map[string]error{
+		"postgres": errors.New("dial tcp: connection refused"),
+		"cache":    errors.New("line one\nline two"),
+	})
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"status":"DOWN","details":{"cache":{"status":"DOWN","error":"line one line two"},` +
+		`"postgres":{"status":"DOWN","error":"dial tcp: connection refused"}}}`
+	if string(body) != want {
+		t.Errorf("Expected %s, got %s", want, body)
+	}
+}
+
+func TestErrorKind(t *testing.T) {
+	for err, want := range map[error]string{
+		&ExpiredError{Name: "db"}:        "expired",
+		ErrStaleData:                     "stale",
+		context.DeadlineExceeded:         "timeout",
+		ErrHealthCheckTimeout:            "timeout",
+		ErrCheckCanceled:                 "canceled",
+		errors.New("connection refused"): "error",
+		Degraded(quotaError{}):           "quota",
+		nil:                              "not_checked",
+	} {
+		if got := errorKind(err); got != want {
+			t.Errorf("errorKind(%v) = %q, want %q", err, got, want)
+		}
+	}
+}
+
+func TestDetailOrder(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		name  string
+		order DetailOrder
+		want  []string
+	}{
+		{"by priority", OrderByPriority, []string{"a-db", "z-db", "cache", "search"}},
+		{"by name", OrderByName, []string{"a-db", "cache", "search", "z-db"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ha := NewHealthAggregator(ctx, WithDetailOrder(tc.order))
+			ha.RegisterHealthCheck(&mockHealthChecker{name: "search"}, PriorityLow)
+			ha.RegisterHealthCheck(&mockHealthChecker{name: "z-db"}, PriorityCritical)
+			ha.RegisterHealthCheck(&mockHealthChecker{name: "cache"}, PriorityHigh)
+			ha.RegisterHealthCheck(&mockHealthChecker{name: "a-db"}, PriorityCritical)
+
+			for i := 0; i < 5; i++ {
+				var names []string
+				for _, check := range ha.DetailedReadinessResponse().Checks {
+					names = append(names, check.Name)
+				}
+				if !slices.Equal(names, tc.want) {
+					t.Fatalf("Expected %v, got %v", tc.want, names)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckDescription(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	ha.RegisterHealthCheckWithOptions(&mockHealthChecker{name: "pg-main"}, PriorityCritical,
+		CheckOptions{Description: "Primary Postgres connection pool"})
+
+	if detail := ha.DetailedReadinessResponse().Checks[0]; detail.Description != "Primary Postgres connection pool" {
+		t.Errorf("Expected the description in detailed responses, got %+v", detail)
+	}
+
+	recorder := httptest.NewRecorder()
+	ha.DashboardHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	if body := recorder.Body.String(); !strings.Contains(body, `<div class="description">Primary Postgres connection pool</div>`) {
+		t.Errorf("Expected the description on the dashboard, got:\n%s", body)
+	}
+}
@@ -0,0 +1,67 @@
+package gopulse
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ErrDraining is reported as the readiness error while the drain file exists or after
+// BeginShutdown
+var ErrDraining = errors.New("draining")
+
+// drainingCheckName is the key of the draining error in readiness error maps
+const drainingCheckName = "draining"
+
+// drainPollInterval is how often the drain file is looked for
+const drainPollInterval = 500 * time.Millisecond
+
+// Draining reports whether the drain file currently exists or shutdown has begun
+func (ha *HealthAggregator) Draining() bool {
+	return ha.draining.Load() || ha.shuttingDown.Load()
+}
+
+// drainError returns the readiness error while draining, nil otherwise
+func (ha *HealthAggregator) drainError() error {
+	if ha.shuttingDown.Load() {
+		return fmt.Errorf("%w: shutting down", ErrDraining)
+	}
+	if !ha.draining.Load() {
+		return nil
+	}
+	return fmt.Errorf("%w: %s exists", ErrDraining, ha.config.DrainFile)
+}
+
+// watchDrainFile polls DrainFile until the aggregator stops, draining while it exists
+func (ha *HealthAggregator) watchDrainFile() {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ha.ctx.Done():
+			return
+		case <-ticker.C:
+			ha.checkDrainFile()
+		}
+	}
+}
+
+// checkDrainFile updates the draining state from the existence of DrainFile, logging changes
+func (ha *HealthAggregator) checkDrainFile() {
+	_, err := os.Stat(ha.config.DrainFile)
+	draining := err == nil
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		ha.logger().Warn("drain file check failed", "path", ha.config.DrainFile, "error", err)
+		return
+	}
+	if ha.draining.Swap(draining) == draining {
+		return
+	}
+	if draining {
+		ha.logger().Info("drain file found, reporting not ready", "path", ha.config.DrainFile)
+	} else {
+		ha.logger().Info("drain file removed, readiness restored", "path", ha.config.DrainFile)
+	}
+}
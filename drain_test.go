@@ -0,0 +1,71 @@
+package gopulse
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDrainFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "drain")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx, WithDrainFile(path))
+	checker := &mockHealthChecker{name: "db"}
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(checker, nil, nil)
+	time.Sleep(50 * time.Millisecond)
+
+	// The file is checked on Start, before the first poll
+	ready, errs := ha.GetReadiness()
+	if ready || !errors.Is(errs["draining"], ErrDraining) {
+		t.Errorf("Expected drain file to fail readiness, got %v %v", ready, errs)
+	}
+	if response := ha.ReadinessResponse(); response.Maintenance || !strings.HasPrefix(response.Reason, "draining: ") {
+		t.Errorf("Expected a draining response not flagged as maintenance, got %+v", response)
+	}
+	if live, _ := ha.GetLiveness(); !live {
+		t.Error("Expected liveness to be unaffected by draining")
+	}
+	if !ha.Draining() {
+		t.Error("Expected Draining to be true")
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, 2*time.Second, func() bool { return !ha.Draining() })
+	if ready, errs := ha.GetReadiness(); !ready {
+		t.Errorf("Expected readiness to reflect checks once the drain file is removed, got %v", errs)
+	}
+
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, 2*time.Second, ha.Draining)
+	if response := ha.ReadinessResponse(); response.Status != StatusDown {
+		t.Errorf("Expected down while draining, got %+v", response)
+	}
+}
+
+// waitFor polls cond until it holds or timeout elapses
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before timeout")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
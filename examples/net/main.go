@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/nduyhai/gopulse"
 	"github.com/nduyhai/gopulse/healths"
@@ -36,36 +35,12 @@ func main() {
 	down := &healths.Down{}
 	aggregator.RegisterHealthCheck(down, gopulse.PriorityCritical)
 
-	// Register the handler function for the root path "/"
-	http.HandleFunc("/readiness", func(w http.ResponseWriter, r *http.Request) {
-		readiness, errors := aggregator.GetReadiness()
-		w.WriteHeader(http.StatusOK)
-		if readiness {
-			strB, _ := json.Marshal(gopulse.NewUpStatus())
-			_, _ = w.Write(strB)
-			return
-		}
-		strB, _ := json.Marshal(gopulse.NewDownStatus(errors))
-		_, _ = w.Write(strB)
-		return
+	// Serve the probes, answering 503 when down
+	http.Handle("/readiness", aggregator.ReadinessHandler())
+	http.Handle("/liveness", aggregator.LivenessHandler())
 
-	})
-
-	// Register the handler function for the root path "/"
-	http.HandleFunc("/liveness", func(w http.ResponseWriter, r *http.Request) {
-		readiness, errors := aggregator.GetLiveness()
-		w.WriteHeader(http.StatusOK)
-		if readiness {
-			strB, _ := json.Marshal(gopulse.NewUpStatus())
-			_, _ = w.Write(strB)
-			return
-		}
-
-		strB, _ := json.Marshal(gopulse.NewDownStatus(errors))
-		_, _ = w.Write(strB)
-		return
-
-	})
+	// Or mount both at /livez and /readyz
+	http.Handle("/", aggregator.Handler())
 
 	// Start the server on port 8080
 	fmt.Println("Server starting on port 8080...")
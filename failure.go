@@ -0,0 +1,35 @@
+package gopulse
+
+import "errors"
+
+// permanent is implemented by errors that declare they won't heal by retrying. Unlike the
+// deprecated net.Error Temporary method, which reports false for a refused connection, it
+// must be implemented explicitly.
+type permanent interface {
+	Permanent() bool
+}
+
+// permanentError marks an error that won't heal by retrying
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string   { return e.err.Error() }
+func (e *permanentError) Unwrap() error   { return e.err }
+func (e *permanentError) Permanent() bool { return true }
+
+// Permanent wraps a check error, such as misconfigured credentials, that won't heal by retrying.
+// Instead of growing exponentially, the check's backoff jumps straight to MaxBackoff.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// isPermanent reports whether an error declares itself permanent. Errors that don't
+// implement Permanent() bool are treated as transient.
+func isPermanent(err error) bool {
+	var p permanent
+	return errors.As(err, &p) && p.Permanent()
+}
@@ -0,0 +1,149 @@
+package gopulse
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// HealthScoreHeader carries the readiness score when WithScoreHeader is enabled
+const HealthScoreHeader = "X-Health-Score"
+
+// Score returns the weighted share of ready checks, from 0 (nothing ready) to 1 (all ready).
+// Each non-informational check weighs 1/(1+priority), with priorities below critical weighing
+// as critical, so a failing critical check lowers the score more than a failing low-priority
+// one. With no checks registered the score is 1.
+func (ha *HealthAggregator) Score() float64 {
+	score, _ := ha.scoreByPriority()
+	return score
+}
+
+// scoreByPriority returns the readiness score and the number of ready checks per priority
+func (ha *HealthAggregator) scoreByPriority() (float64, map[Priority]int) {
+	ha.mu.RLock()
+	defer ha.mu.RUnlock()
+
+	var total, ready float64
+	up := make(map[Priority]int)
+	now := ha.config.Clock.Monotonic()
+	for name, status := range ha.statuses {
+		if !status.affectsHealth() {
+			continue
+		}
+		weight := 1 / float64(1+max(status.Priority, PriorityCritical))
+		total += weight
+		if ok, _ := ha.statusReadiness(name, status, now); ok {
+			ready += weight
+			up[status.Priority]++
+		}
+	}
+	if total == 0 {
+		return 1, up
+	}
+	return ready / total, up
+}
+
+// ResponseEncoder writes a probe result in a custom JSON shape, such as Spring Boot actuator's.
+// details holds a copy of the status of every non-informational check, with the probed
+// field (Liveness/LivenessErr or Readiness/ReadinessErr) set to the check's probe result.
+type ResponseEncoder interface {
+	Encode(w io.Writer, up bool, details map[string]*HealthStatus) error
+}
+
+// LivenessHandler serves LivenessResponse as JSON, with status 503 when not live
+func (ha *HealthAggregator) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ha.config.HealthJSONFormat {
+			ha.writeHealthJSON(w, ha.LivenessResponse(), ha.statusLiveness, true)
+			return
+		}
+		if encoder := ha.config.ResponseEncoder; encoder != nil {
+			up, details := ha.probeDetails(ha.statusLiveness, true)
+			writeEncoded(w, encoder, up, details)
+			return
+		}
+		ha.writeResult(w, ha.livenessResult())
+	})
+}
+
+// ReadinessHandler serves ReadinessResponse as JSON, with status 503 when not ready.
+// With WithScoreHeader the readiness score is also sent in the X-Health-Score header.
+func (ha *HealthAggregator) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ha.config.ScoreHeader {
+			w.Header().Set(HealthScoreHeader, strconv.FormatFloat(ha.Score(), 'f', 2, 64))
+		}
+		if ha.config.HealthJSONFormat {
+			ha.writeHealthJSON(w, ha.ReadinessResponse(), ha.statusReadiness, false)
+			return
+		}
+		if encoder := ha.config.ResponseEncoder; encoder != nil {
+			up, details := ha.probeDetails(ha.statusReadiness, false)
+			if _, err := ha.readinessOverride(); err != nil {
+				up = false
+			}
+			writeEncoded(w, encoder, up, details)
+			return
+		}
+		ha.writeResult(w, ha.readinessResult())
+	})
+}
+
+// Handler serves LivenessHandler at /livez and ReadinessHandler at /readyz, for mounting both
+// probes at once, e.g. http.ListenAndServe(":8080", ha.Handler())
+func (ha *HealthAggregator) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/livez", ha.LivenessHandler())
+	mux.Handle("/readyz", ha.ReadinessHandler())
+	return mux
+}
+
+// probeDetails evaluates a probe for every non-informational check, returning the overall
+// result and status copies carrying each check's probe result
+func (ha *HealthAggregator) probeDetails(probe probeFunc, liveness bool) (bool, map[string]*HealthStatus) {
+	ha.mu.RLock()
+	defer ha.mu.RUnlock()
+
+	up := true
+	details := make(map[string]*HealthStatus, len(ha.statuses))
+	now := ha.config.Clock.Monotonic()
+	for name, status := range ha.statuses {
+		if !status.affectsHealth() {
+			continue
+		}
+		ok, err := probe(name, status, now)
+		snapshot := *status
+		if liveness {
+			snapshot.Liveness, snapshot.LivenessErr = ok, err
+		} else {
+			snapshot.Readiness, snapshot.ReadinessErr = ok, err
+		}
+		details[name] = &snapshot
+		up = up && ok
+	}
+	return up, details
+}
+
+// writeEncoded writes a probe result with a custom encoder and a status code matching it
+func writeEncoded(w http.ResponseWriter, encoder ResponseEncoder, up bool, details map[string]*HealthStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	if !up {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = encoder.Encode(w, up, details)
+}
+
+// statusCode returns the HTTP status code of a response with the given status
+func (ha *HealthAggregator) statusCode(status Status) int {
+	switch status {
+	case StatusUp:
+		return http.StatusOK
+	case StatusDegraded:
+		if code := ha.config.DegradedStatusCode; code != 0 {
+			return code
+		}
+		return http.StatusOK
+	default:
+		return http.StatusServiceUnavailable
+	}
+}
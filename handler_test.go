@@ -0,0 +1,183 @@
+package gopulse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestScore(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	ha.Start()
+	defer ha.Stop()
+
+	if score := ha.Score(); score != 1 {
+		t.Errorf("Expected score 1 with no checks, got %v", score)
+	}
+
+	critical := &mockHealthChecker{name: "critical"}
+	low := &mockHealthChecker{name: "low"}
+	ha.RegisterHealthCheck(critical, PriorityCritical)
+	ha.RegisterHealthCheck(low, PriorityLow)
+	ha.UpdateHealth(critical, nil, nil)
+	ha.UpdateHealth(low, nil, errors.New("not ready"))
+	time.Sleep(100 * time.Millisecond)
+
+	// Weights are 1 for critical and 1/4 for low
+	if score := ha.Score(); math.Abs(score-0.8) > 1e-9 {
+		t.Errorf("Expected score 0.8, got %v", score)
+	}
+}
+
+func TestReadinessHandler(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx, WithScoreHeader(true))
+	ha.Start()
+	defer ha.Stop()
+
+	checker := &mockHealthChecker{name: "db"}
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.UpdateHealth(checker, nil, errors.New("timeout"))
+	time.Sleep(100 * time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	ha.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readiness", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503, got %d", rec.Code)
+	}
+	if got := rec.Header().Get(HealthScoreHeader); got != "0.00" {
+		t.Errorf("Expected score header 0.00, got %q", got)
+	}
+	var response PulseResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Status != StatusDown || response.Details["db"] != StatusDown {
+		t.Errorf("Unexpected response %+v", response)
+	}
+
+	rec = httptest.NewRecorder()
+	ha.LivenessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/liveness", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected 200 for liveness, got %d", rec.Code)
+	}
+	if rec.Header().Get(HealthScoreHeader) != "" {
+		t.Error("Expected no score header on liveness")
+	}
+}
+
+// actuatorEncoder writes Spring Boot actuator style responses
+type actuatorEncoder struct{}
+
+func (actuatorEncoder) Encode(w io.Writer, up bool, details map[string]*HealthStatus) error {
+	status := func(ok bool) string {
+		if ok {
+			return "UP"
+		}
+		return "OUT_OF_SERVICE"
+	}
+	components := make(map[string]map[string]string, len(details))
+	for name, check := range details {
+		components[name] = map[string]string{"status": status(check.Readiness)}
+	}
+	return json.NewEncoder(w).Encode(map[string]any{"status": status(up), "components": components})
+}
+
+func TestResponseEncoder(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx, WithResponseEncoder(actuatorEncoder{}))
+	ha.Start()
+	defer ha.Stop()
+
+	db := &mockHealthChecker{name: "db"}
+	cache := &mockHealthChecker{name: "cache"}
+	ha.RegisterHealthCheck(db, PriorityCritical)
+	ha.RegisterHealthCheck(cache, PriorityLow)
+	ha.UpdateHealth(db, nil, nil)
+	ha.UpdateHealth(cache, nil, errors.New("timeout"))
+	time.Sleep(100 * time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	ha.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readiness", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503, got %d", rec.Code)
+	}
+	want := `{"components":{"cache":{"status":"OUT_OF_SERVICE"},"db":{"status":"UP"}},"status":"OUT_OF_SERVICE"}` + "\n"
+	if rec.Body.String() != want {
+		t.Errorf("Expected %s, got %s", want, rec.Body.String())
+	}
+}
+
+func TestHandler(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	ha.Start()
+	defer ha.Stop()
+
+	checker := &mockHealthChecker{name: "db"}
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.UpdateHealth(checker, nil, errors.New("timeout"))
+	time.Sleep(100 * time.Millisecond)
+
+	handler := ha.Handler()
+	for path, want := range map[string]int{
+		"/livez":   http.StatusOK,
+		"/readyz":  http.StatusServiceUnavailable,
+		"/healthz": http.StatusNotFound,
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != want {
+			t.Errorf("Expected %d for %s, got %d", want, path, rec.Code)
+		}
+		if want != http.StatusNotFound && rec.Header().Get("Content-Type") != "application/json" {
+			t.Errorf("Expected JSON for %s, got %q", path, rec.Header().Get("Content-Type"))
+		}
+	}
+}
+
+func TestProbeHandlersStatusCodes(t *testing.T) {
+	ha := NewHealthAggregator(context.Background())
+	ha.Start()
+	defer ha.Stop()
+
+	checker := &mockHealthChecker{name: "worker"}
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+
+	get := func(handler http.Handler) (int, PulseResponse) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Expected application/json, got %q", ct)
+		}
+		var response PulseResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+		return rec.Code, response
+	}
+
+	ha.UpdateHealth(checker, nil, nil)
+	time.Sleep(100 * time.Millisecond)
+	for _, handler := range []http.Handler{ha.LivenessHandler(), ha.ReadinessHandler()} {
+		if code, response := get(handler); code != http.StatusOK || response.Status != StatusUp {
+			t.Errorf("Expected 200 UP while healthy, got %d %+v", code, response)
+		}
+	}
+
+	ha.UpdateHealth(checker, errors.New("deadlocked"), nil)
+	time.Sleep(100 * time.Millisecond)
+	if code, response := get(ha.LivenessHandler()); code != http.StatusServiceUnavailable || response.Status != StatusDown {
+		t.Errorf("Expected 503 DOWN while not live, got %d %+v", code, response)
+	}
+}
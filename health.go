@@ -1,5 +1,10 @@
 package gopulse
 
+import (
+	"context"
+	"time"
+)
+
 // HealthChecker defines an interface for performing liveness and readiness checks for a system or service.
 // Name provides the identifier or name of the health check.
 // CheckLiveness checks if the system or service is alive and reachable.
@@ -15,16 +20,76 @@ type HealthChecker interface {
 	CheckReadiness() error
 }
 
+// ContextHealthChecker can optionally be implemented by a HealthChecker doing network I/O, so
+// its checks can be canceled and are bounded by WithCheckTimeout. When implemented, the
+// aggregator calls these instead of CheckLiveness and CheckReadiness. Auto-update checks get a
+// context canceled by Stop, so no auto-update check outlives the aggregator. On-demand checks
+// (Validate, GetReadinessContext, RunAllWithBudget) get the caller's context instead, with its
+// deadline, and keep working after Stop like plain checks.
+type ContextHealthChecker interface {
+	CheckLivenessContext(ctx context.Context) error
+	CheckReadinessContext(ctx context.Context) error
+}
+
+// FreshnessReporter can optionally be implemented by a HealthChecker whose readiness depends on
+// data it refreshes in the background, such as a cache. DataAge reports how old that data is.
+// It is called while aggregating readiness, so it must be cheap and must not block.
+type FreshnessReporter interface {
+	DataAge() time.Duration
+}
+
+// DurationReporter can optionally be implemented by a HealthChecker to report its own
+// duration measurement, such as an average round-trip time, instead of the time the
+// aggregator measured around the check. It is exposed as HealthStatus.Duration.
+type DurationReporter interface {
+	ReportedDuration() time.Duration
+}
+
+// DetailProvider can optionally be implemented by a HealthChecker to report a structured
+// breakdown, such as primary and replica lag of a database. Details is collected with every
+// result and shown under the check's entry in detailed responses.
+type DetailProvider interface {
+	Details() map[string]string
+}
+
+// EndpointReporter can optionally be implemented by a HealthChecker to report the endpoints
+// of the dependency it checks, such as URLs or host:port addresses. They are reported as the
+// check's affectedEndpoints in health+json responses.
+type EndpointReporter interface {
+	Endpoints() []string
+}
+
+// ExpiryPolicy can optionally be implemented by a HealthChecker with an irregular reporting
+// cadence, such as an hourly cron job, to decide itself when its last result is too old. It
+// replaces the configured expiry time for that checker; the registration grace period and
+// ExtendExpiry still apply. Times are wall clock times from the aggregator's Clock.
+type ExpiryPolicy interface {
+	IsExpired(lastUpdate time.Time, now time.Time) bool
+}
+
 type Status string
 
 const (
-	StatusUp   Status = "UP"
-	StatusDown Status = "DOWN"
+	StatusUp      Status = "UP"
+	StatusDown    Status = "DOWN"
+	StatusUnknown Status = "UNKNOWN"
+	// StatusDegraded reports a service that is serving but partially degraded
+	StatusDegraded Status = "DEGRADED"
+	// StatusInactive marks a conditional check skipped because its condition is false
+	StatusInactive Status = "INACTIVE"
 )
 
+// PulseResponse is the JSON body of probe responses. The handlers stream it with
+// pulseResult.encode, so a new field must be added there as well.
 type PulseResponse struct {
 	Status  Status            `json:"status"`
+	Reason  string            `json:"reason,omitempty"`
 	Details map[string]Status `json:"details,omitempty"`
+	// Omitted counts failing checks left out of Details to keep the response bounded
+	Omitted int `json:"omitted,omitempty"`
+	// Maintenance is set when the service is down for planned maintenance rather than a failure
+	Maintenance bool       `json:"maintenance,omitempty"`
+	Build       *BuildInfo `json:"build,omitempty"`
 }
 
 func NewDownStatus(errors map[string]error) *PulseResponse {
@@ -38,6 +103,34 @@ func NewDownStatus(errors map[string]error) *PulseResponse {
 	}
 }
 
+// ComponentStatus is the entry of one check in a PulseErrorResponse
+type ComponentStatus struct {
+	Status Status `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// PulseErrorResponse is a PulseResponse whose details include each failing check's error
+// message, e.g. {"status":"DOWN","details":{"postgres":{"status":"DOWN","error":"dial tcp:
+// connection refused"}}}. Error messages may reveal internals, so only serve it to trusted
+// clients.
+type PulseErrorResponse struct {
+	Status  Status                     `json:"status"`
+	Details map[string]ComponentStatus `json:"details,omitempty"`
+}
+
+// NewDetailedDownStatus is NewDownStatus reporting each error's message, sanitized to a single
+// line and truncated like in detailed responses
+func NewDetailedDownStatus(errs map[string]error) *PulseErrorResponse {
+	details := make(map[string]ComponentStatus, len(errs))
+	for name, err := range errs {
+		details[name] = ComponentStatus{Status: StatusDown, Error: sanitizeError(err)}
+	}
+	return &PulseErrorResponse{
+		Status:  StatusDown,
+		Details: details,
+	}
+}
+
 func NewUpStatus() *PulseResponse {
 	return &PulseResponse{
 		Status: StatusUp,
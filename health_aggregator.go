@@ -3,11 +3,19 @@ package gopulse
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
-// Priority represents the importance level of a health check
+// Priority represents the importance level of a health check. Lower values are more
+// critical; any integer works, the named levels are just common choices.
 type Priority int
 
 const (
@@ -17,6 +25,23 @@ const (
 	PriorityLow
 )
 
+// String returns the lowercase name of a priority level, e.g. "critical", or "priority N"
+// for custom levels
+func (p Priority) String() string {
+	switch p {
+	case PriorityCritical:
+		return "critical"
+	case PriorityHigh:
+		return "high"
+	case PriorityMedium:
+		return "medium"
+	case PriorityLow:
+		return "low"
+	default:
+		return fmt.Sprintf("priority %d", int(p))
+	}
+}
+
 // HealthStatus represents the current state of a health check
 type HealthStatus struct {
 	Checker      HealthChecker
@@ -26,19 +51,136 @@ type HealthStatus struct {
 	LastUpdate   time.Time
 	LivenessErr  error
 	ReadinessErr error
+	// Duration is how long the last check took, or the value reported by a DurationReporter
+	Duration time.Duration
+	// SkippedRuns counts scheduled checks skipped since the last result because the
+	// previous check was still running
+	SkippedRuns int
+	// Informational checks are tracked and reported but never affect overall health
+	Informational bool
+	// Details reported by a DetailProvider with the last result
+	Details map[string]string
+	// FailingSince is when the check started failing, zero while it passes
+	FailingSince time.Time
+	// LastSuccess is when the check last passed both probes, zero if it never did
+	LastSuccess time.Time
+	// Description is the human-readable description set at registration
+	Description string
+	// Tags set at registration
+	Tags []string
+	// Soft is set for optional dependencies, whose failures only degrade readiness
+	Soft bool
+	// NoExpiry is set for checks whose last result never expires
+	NoExpiry bool
+	// Inactive is set while a conditional check is skipped because its condition is false;
+	// like informational checks, it then doesn't affect overall health
+	Inactive bool
+	// CircuitState tells whether auto-update is backing off from this check
+	CircuitState CircuitState
+	// NextProbe is when an open circuit will be probed again, zero otherwise
+	NextProbe time.Time
+	// updatedAt is the monotonic clock reading matching LastUpdate
+	updatedAt time.Duration
+	// unreadySince is the monotonic clock reading when reported readiness started failing
+	unreadySince time.Duration
+	// checked is false until the first update after registration
+	checked bool
 }
 
-// Config holds the configuration for the HealthAggregator
+// Config holds the configuration for the HealthAggregator. Callbacks and live references
+// are excluded from JSON.
 type Config struct {
-	ExpiryTime     time.Duration
-	UpdateBuffer   int
-	OnStatusChange func(name string, status *HealthStatus)
+	ExpiryTime        time.Duration
+	ExpiryByPriority  map[Priority]time.Duration
+	RegistrationGrace time.Duration
+	// ExpiryMissedChecks, when positive, replaces ExpiryTime with that many CheckIntervals
+	ExpiryMissedChecks int
+	UpdateBuffer       int
+	OnStatusChange     func(name string, status *HealthStatus) `json:"-"`
+	// ChangeCallbackThrottle, when positive, is the minimum time between two OnStatusChange
+	// calls for the same checker
+	ChangeCallbackThrottle time.Duration
+	// ResultObservers are called with every applied check result, e.g. by metrics integrations,
+	// and with a nil status when a check is removed
+	ResultObservers []func(name string, status *HealthStatus) `json:"-"`
+	// BeforeCheck and AfterCheck are called around every check the aggregator runs
+	BeforeCheck func(name string)                                             `json:"-"`
+	AfterCheck  func(name string, liveness, readiness error, d time.Duration) `json:"-"`
 	// Auto update configuration
 	AutoUpdateEnabled bool
 	CheckInterval     time.Duration
 	InitialDelay      time.Duration
 	MaxBackoff        time.Duration
 	BackoffFactor     float64
+	// RecoveryProbeInterval, when positive, bounds how long backoff may skip a failing check
+	RecoveryProbeInterval time.Duration
+	ConcurrentProbes      bool
+	// MaxConcurrency bounds how many checks an auto-update round runs at once, zero means
+	// no limit
+	MaxConcurrency int
+	// CheckTimeout, when positive, bounds each liveness and readiness call of a check
+	CheckTimeout time.Duration
+	// Freshness configuration, zero disables it
+	MaxDataAge time.Duration
+	// MaxReportedErrors bounds the failures included in responses, zero means unlimited
+	MaxReportedErrors int
+	// IncludeBuildInfo adds the Go version and module build info to responses
+	IncludeBuildInfo bool
+	// ResultInterceptor may transform check results before they are stored
+	ResultInterceptor func(name string, livenessErr, readinessErr error) (error, error) `json:"-"`
+	// AuditLog receives a JSON line for every check state transition
+	AuditLog io.Writer `json:"-"`
+	// ResultRecorder receives a line in RecordFormat for every check result
+	ResultRecorder io.Writer `json:"-"`
+	RecordFormat   RecordFormat
+	// Clock used for timestamps and measuring ages
+	Clock Clock `json:"-"`
+	// Overall readiness transition callbacks
+	OnReady           func() `json:"-"`
+	OnNotReady        func() `json:"-"`
+	ReadinessDebounce time.Duration
+	// StuckCheckThreshold is how long a check may run before a warning is logged,
+	// zero means three times the CheckTimeout, or the CheckInterval without one
+	StuckCheckThreshold time.Duration
+	// Logger receives warnings and heartbeats, nil means slog.Default()
+	Logger *slog.Logger `json:"-"`
+	// HeartbeatInterval is how often a status summary is logged, zero disables it
+	HeartbeatInterval time.Duration
+	// DrainFile, when set, is polled for existence; readiness is down while it exists
+	DrainFile string
+	// StabilizationPeriod is how long checks must pass after startup before reporting ready
+	StabilizationPeriod time.Duration
+	// PreStopReadinessDelay is how long ShutdownOnSignal reports not ready before its context
+	// is canceled
+	PreStopReadinessDelay time.Duration
+	// Statsd server address and metric prefix; an empty address disables statsd reporting
+	StatsdAddr   string
+	StatsdPrefix string
+	// SharedStore receives this process's health every SharedInterval under SharedID
+	// (the process id when empty), for SharedReadiness across processes
+	SharedStore    SharedStore `json:"-"`
+	SharedID       string
+	SharedInterval time.Duration
+	// Scheduler decides when auto-update runs each checker, nil means every CheckInterval
+	Scheduler Scheduler `json:"-"`
+	// DegradedStatusCode is the HTTP status of DEGRADED readiness responses, zero means 200
+	DegradedStatusCode int
+	// ErrorDetails adds each failing check's error, kind and failing-since time to detailed responses
+	ErrorDetails bool
+	// ResponseEncoder formats handler responses, nil means PulseResponse JSON
+	ResponseEncoder ResponseEncoder `json:"-"`
+	// HealthJSONFormat makes handlers respond in the application/health+json format
+	HealthJSONFormat bool
+	// DetailOrder is the order of checks in detailed responses and the dashboard
+	DetailOrder DetailOrder
+	// ScoreHeader adds the readiness score as an X-Health-Score header to readiness responses
+	ScoreHeader bool
+	// LivenessFromReadiness fails a check's liveness once its readiness has failed continuously
+	// for this long, zero disables it
+	LivenessFromReadiness time.Duration
+	// CallbackTimeout is how long the update goroutine waits for a callback before leaving it
+	// running on its own, zero means waiting until it returns
+	CallbackTimeout time.Duration
 }
 
 // Option is a function that configures the HealthAggregator
@@ -51,7 +193,33 @@ func WithExpiryTime(d time.Duration) Option {
 	}
 }
 
-// WithUpdateBuffer sets the size of the update channel buffer
+// WithExpiryMissedChecks expires checks after n missed check intervals instead of a fixed
+// ExpiryTime, so the expiry follows CheckInterval when it is tuned. Expiry times set per
+// priority still take precedence.
+func WithExpiryMissedChecks(n int) Option {
+	return func(c *Config) {
+		c.ExpiryMissedChecks = n
+	}
+}
+
+// WithExpiryByPriority sets expiry times per priority level. Priorities missing
+// from the map fall back to the global expiry time.
+func WithExpiryByPriority(expiry map[Priority]time.Duration) Option {
+	return func(c *Config) {
+		c.ExpiryByPriority = maps.Clone(expiry)
+	}
+}
+
+// WithRegistrationGrace sets a grace period after registration during which a checker
+// that has never been checked isn't considered expired, smoothing startup for short expiry times
+func WithRegistrationGrace(d time.Duration) Option {
+	return func(c *Config) {
+		c.RegistrationGrace = d
+	}
+}
+
+// WithUpdateBuffer sets how many checkers can have an update pending at once. Pending
+// updates are coalesced per checker, so this bounds memory regardless of the update rate.
 func WithUpdateBuffer(size int) Option {
 	return func(c *Config) {
 		c.UpdateBuffer = size
@@ -65,6 +233,45 @@ func WithStatusChangeCallback(callback func(name string, status *HealthStatus))
 	}
 }
 
+// WithBeforeCheck sets a hook called on the checking goroutine right before every check the
+// aggregator runs, by auto-update or on demand, whether or not its result changes, e.g. to
+// start a trace span. Checks skipped for backoff or overlap and results pushed through
+// UpdateHealth don't call it.
+func WithBeforeCheck(hook func(name string)) Option {
+	return func(c *Config) {
+		c.BeforeCheck = hook
+	}
+}
+
+// WithAfterCheck sets a hook called on the checking goroutine after every check the aggregator
+// runs with its raw results and duration, before any ResultInterceptor rewrites them and
+// before they are applied, e.g. for custom metrics
+func WithAfterCheck(hook func(name string, liveness, readiness error, d time.Duration)) Option {
+	return func(c *Config) {
+		c.AfterCheck = hook
+	}
+}
+
+// WithChangeCallbackThrottle calls the status change callback at most once per d for each
+// checker. Statuses arriving within d of the last call are collapsed into the latest one,
+// delivered once d has passed, so a flapping check can't cause an alert storm but the
+// callback still ends up with its current state.
+func WithChangeCallbackThrottle(d time.Duration) Option {
+	return func(c *Config) {
+		c.ChangeCallbackThrottle = d
+	}
+}
+
+// WithResultObserver adds an observer called with every applied check result, like the status
+// change callback but cumulative, so integrations such as metrics exporters can each add one.
+// When a check is removed by UnregisterHealthCheck or ReplaceAll, it is called with a nil
+// status so the check's state can be dropped.
+func WithResultObserver(observer func(name string, status *HealthStatus)) Option {
+	return func(c *Config) {
+		c.ResultObservers = append(c.ResultObservers, observer)
+	}
+}
+
 // WithAutoUpdate enables automatic health checking with the specified interval
 func WithAutoUpdate(interval time.Duration) Option {
 	return func(c *Config) {
@@ -80,7 +287,18 @@ func WithInitialDelay(delay time.Duration) Option {
 	}
 }
 
-// WithBackoff sets the backoff configuration for failed checks
+// WithRecoveryProbeInterval checks a failing checker at least every d even while its backoff is
+// longer, e.g. pinned at MaxBackoff during a long outage, so its recovery is detected promptly.
+// The backoff itself keeps growing; d only bounds how long it skips the check.
+func WithRecoveryProbeInterval(d time.Duration) Option {
+	return func(c *Config) {
+		c.RecoveryProbeInterval = d
+	}
+}
+
+// WithBackoff sets the backoff configuration for failed checks. After a failure a checker
+// is skipped for one CheckInterval, multiplied by factor on every further failure up to
+// maxBackoff, so factor should be above 1 and maxBackoff at least the CheckInterval.
 func WithBackoff(maxBackoff time.Duration, factor float64) Option {
 	return func(c *Config) {
 		c.MaxBackoff = maxBackoff
@@ -88,6 +306,278 @@ func WithBackoff(maxBackoff time.Duration, factor float64) Option {
 	}
 }
 
+// WithMaxConcurrency bounds how many checkers auto-update checks at once. Checkers due in the
+// same round run concurrently, so one slow checker doesn't delay the others; n limits the
+// goroutines (and connections) used by a service with many dependencies. Zero means no limit.
+func WithMaxConcurrency(n int) Option {
+	return func(c *Config) {
+		c.MaxConcurrency = n
+	}
+}
+
+// WithConcurrentProbes runs a checker's liveness and readiness checks in parallel
+// rather than one after the other. Only enable it when every registered checker's
+// two checks are independent and safe to run concurrently.
+func WithConcurrentProbes(enabled bool) Option {
+	return func(c *Config) {
+		c.ConcurrentProbes = enabled
+	}
+}
+
+// WithMaxDataAge fails readiness for checkers implementing FreshnessReporter
+// whose reported data age exceeds maxAge, even if their last check succeeded
+func WithMaxDataAge(maxAge time.Duration) Option {
+	return func(c *Config) {
+		c.MaxDataAge = maxAge
+	}
+}
+
+// WithResultInterceptor sets a function called with every check result, from both
+// auto-update and UpdateHealth, before it is stored. The returned errors replace the
+// original results, e.g. to demote a known error to healthy or to inject a failure. If it
+// panics, the panic is logged and the original results are stored.
+func WithResultInterceptor(interceptor func(name string, livenessErr, readinessErr error) (error, error)) Option {
+	return func(c *Config) {
+		c.ResultInterceptor = interceptor
+	}
+}
+
+// WithAuditLog appends a JSON line to w for every check state transition (time, name,
+// from, to, error, duration), e.g. for shipping to a SIEM. Writes happen in the background
+// so a slow writer never stalls update processing; lines are dropped if it falls too far behind.
+func WithAuditLog(w io.Writer) Option {
+	return func(c *Config) {
+		c.AuditLog = w
+	}
+}
+
+// WithResultRecorder appends every check result (time, name, liveness, readiness, duration,
+// error) to w as JSON lines or CSV, a cheap local history for offline analysis such as daily
+// availability. Like the audit log, writes happen in the background and are dropped if w
+// falls too far behind; rotating w is up to the caller.
+func WithResultRecorder(w io.Writer, format RecordFormat) Option {
+	return func(c *Config) {
+		c.ResultRecorder = w
+		c.RecordFormat = format
+	}
+}
+
+// WithClock sets the clock used for timestamps, expiry and backoff
+func WithClock(clock Clock) Option {
+	return func(c *Config) {
+		c.Clock = clock
+	}
+}
+
+// WithMaxReportedErrors bounds the number of failing checks included in probe responses and
+// summaries to n, keeping the highest priority ones and counting the rest as omitted
+func WithMaxReportedErrors(n int) Option {
+	return func(c *Config) {
+		c.MaxReportedErrors = n
+	}
+}
+
+// WithBuildInfo includes the Go runtime version and the main module's version in responses,
+// to tell which build a pod is running. It is read once when the aggregator is created.
+func WithBuildInfo(enabled bool) Option {
+	return func(c *Config) {
+		c.IncludeBuildInfo = enabled
+	}
+}
+
+// WithOnReady sets a callback fired when overall readiness transitions to ready,
+// e.g. to register the service in a service registry
+func WithOnReady(callback func()) Option {
+	return func(c *Config) {
+		c.OnReady = callback
+	}
+}
+
+// WithOnNotReady sets a callback fired when overall readiness transitions from ready
+// to not ready, e.g. to deregister the service from a service registry
+func WithOnNotReady(callback func()) Option {
+	return func(c *Config) {
+		c.OnNotReady = callback
+	}
+}
+
+// WithReadinessDebounce only fires OnReady/OnNotReady once a new overall readiness
+// has held for at least d, so brief flaps don't cause repeated register/deregister calls
+func WithReadinessDebounce(d time.Duration) Option {
+	return func(c *Config) {
+		c.ReadinessDebounce = d
+	}
+}
+
+// WithStuckCheckThreshold sets how long a check may run before a warning is logged
+// about it, to find checkers that hang and leak goroutines
+func WithStuckCheckThreshold(d time.Duration) Option {
+	return func(c *Config) {
+		c.StuckCheckThreshold = d
+	}
+}
+
+// WithHeartbeat logs a one-line status summary (overall liveness and readiness, number of
+// checks and failing checks) at info level every interval, as proof of life of the monitoring
+func WithHeartbeat(interval time.Duration) Option {
+	return func(c *Config) {
+		c.HeartbeatInterval = interval
+	}
+}
+
+// WithDrainFile drains the service while the file at path exists: readiness reports down
+// with ErrDraining, liveness is not affected, and readiness reflects the checks again once
+// the file is removed. A Kubernetes preStop hook can then simply `touch` the file.
+func WithDrainFile(path string) Option {
+	return func(c *Config) {
+		c.DrainFile = path
+	}
+}
+
+// WithStabilizationPeriod keeps a newly started service not ready, with ErrStabilizing, until
+// its checks have passed without interruption for d, so a pod in a rolling restart only joins
+// the endpoints once it is stable. It only applies at startup; readiness is re-evaluated at
+// least every second, so d is effectively rounded up to that.
+func WithStabilizationPeriod(d time.Duration) Option {
+	return func(c *Config) {
+		c.StabilizationPeriod = d
+	}
+}
+
+// WithPreStopReadinessDelay sets how long ShutdownOnSignal reports not ready after a signal
+// before canceling its context, so the service is removed from the endpoints while it still
+// serves requests. It should exceed the readiness probe period times its failure threshold.
+func WithPreStopReadinessDelay(d time.Duration) Option {
+	return func(c *Config) {
+		c.PreStopReadinessDelay = d
+	}
+}
+
+// WithLogger sets the logger used for warnings and heartbeats
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Config) {
+		c.Logger = logger
+	}
+}
+
+// WithStatsd pushes check metrics to a statsd server over UDP: liveness and readiness gauges
+// (0/1) and latency for every update, and a counter of state transitions, named
+// "<prefix>.<check>.live", ".ready", ".duration" and ".transitions"
+func WithStatsd(addr, prefix string) Option {
+	return func(c *Config) {
+		c.StatsdAddr = addr
+		c.StatsdPrefix = prefix
+	}
+}
+
+// WithSharedStore publishes this process's overall health to store every interval under id
+// (the process id when empty), so the processes of a prefork server can serve a combined
+// readiness with SharedReadiness
+func WithSharedStore(store SharedStore, id string, interval time.Duration) Option {
+	return func(c *Config) {
+		c.SharedStore = store
+		c.SharedID = id
+		c.SharedInterval = interval
+	}
+}
+
+// WithScheduler sets the strategy deciding when auto-update runs each checker, replacing
+// the fixed CheckInterval; WithAutoUpdate is still needed to enable auto-update
+func WithScheduler(s Scheduler) Option {
+	return func(c *Config) {
+		c.Scheduler = s
+	}
+}
+
+// WithAdaptiveInterval schedules each checker between min and max based on its stability:
+// every min after a state change, backing off towards max while the state holds (see
+// StabilityInterval; unlike the AdaptiveInterval scheduler it doesn't depend on whether the
+// check passes). It replaces any other Scheduler.
+func WithAdaptiveInterval(min, max time.Duration) Option {
+	return func(c *Config) {
+		c.Scheduler = StabilityInterval(min, max)
+	}
+}
+
+// WithDegradedStatusCode sets the HTTP status ReadinessHandler returns while the service is
+// degraded: 200 (the default) keeps traffic flowing, 503 takes the instance out of rotation.
+// The body reports DEGRADED either way.
+func WithDegradedStatusCode(code int) Option {
+	return func(c *Config) {
+		c.DegradedStatusCode = code
+	}
+}
+
+// WithErrorDetails adds structured failure details (sanitized error message, kind and since
+// when it has been failing) to every failing check in detailed responses. Error messages may
+// reveal internals, so only enable it for endpoints that aren't public.
+func WithErrorDetails(enabled bool) Option {
+	return func(c *Config) {
+		c.ErrorDetails = enabled
+	}
+}
+
+// WithResponseEncoder makes LivenessHandler and ReadinessHandler write responses with enc
+// instead of the default PulseResponse JSON
+func WithResponseEncoder(enc ResponseEncoder) Option {
+	return func(c *Config) {
+		c.ResponseEncoder = enc
+	}
+}
+
+// WithDetailOrder sets the order of checks in detailed responses and the dashboard:
+// OrderByPriority (the default, most critical first, then by name) or OrderByName
+func WithDetailOrder(order DetailOrder) Option {
+	return func(c *Config) {
+		c.DetailOrder = order
+	}
+}
+
+// WithHealthJSONFormat makes LivenessHandler and ReadinessHandler respond in the
+// application/health+json format of the IETF "Health Check Response Format for HTTP APIs"
+// draft, understood by some gateways and meshes. It takes precedence over a ResponseEncoder.
+func WithHealthJSONFormat() Option {
+	return func(c *Config) {
+		c.HealthJSONFormat = true
+	}
+}
+
+// WithScoreHeader sends the weighted readiness score (see Score) in an X-Health-Score header
+// on readiness responses, so a proxy such as Envoy or NGINX can shed load gradually
+func WithScoreHeader(enabled bool) Option {
+	return func(c *Config) {
+		c.ScoreHeader = enabled
+	}
+}
+
+// WithLivenessFromReadiness fails a check's liveness once its readiness has been failing
+// continuously for after, so a restart is only triggered by a stuck dependency, not a flapping one
+func WithLivenessFromReadiness(after time.Duration) Option {
+	return func(c *Config) {
+		c.LivenessFromReadiness = after
+	}
+}
+
+// WithCheckTimeout bounds each liveness and readiness call made by auto-update and on-demand
+// checks, independently of each other. A call still running after d is abandoned (left
+// running on its own goroutine) and fails with ErrHealthCheckTimeout, so a checker hanging on
+// a dead connection can't stall the other checks.
+func WithCheckTimeout(d time.Duration) Option {
+	return func(c *Config) {
+		c.CheckTimeout = d
+	}
+}
+
+// WithCallbackTimeout bounds how long OnStatusChange, OnReady, OnNotReady and OnceReady
+// callbacks may block update processing. A callback still running after d is left running on
+// its own goroutine and a warning is logged, so later callbacks may then overlap with it.
+// Panicking callbacks are always recovered and logged.
+func WithCallbackTimeout(d time.Duration) Option {
+	return func(c *Config) {
+		c.CallbackTimeout = d
+	}
+}
+
 // defaultConfig returns the default configuration
 func defaultConfig() *Config {
 	return &Config{
@@ -99,21 +589,74 @@ func defaultConfig() *Config {
 		InitialDelay:      1 * time.Second,
 		MaxBackoff:        30 * time.Second,
 		BackoffFactor:     2.0,
+		Clock:             newSystemClock(),
 	}
 }
 
 // HealthAggregator manages and aggregates health checks
 type HealthAggregator struct {
-	mu            sync.RWMutex
-	statuses      map[string]*HealthStatus
-	config        *Config
-	ctx           context.Context
-	cancel        context.CancelFunc
-	updateChannel chan *HealthStatus
+	mu       sync.RWMutex
+	statuses map[string]*HealthStatus
+	config   *Config
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	// Latest pending update per checker, drained by processUpdates in order of arrival
+	pendingMu    sync.Mutex
+	pending      map[string]*HealthStatus
+	pendingOrder []string
+	// pendingSlots holds a token per pending checker, bounding them to UpdateBuffer
+	pendingSlots  chan struct{}
+	pendingNotify chan struct{}
+	auditLog      *asyncWriter
+	recorder      *asyncWriter
+	statsd        *statsdClient
+	buildInfo     *BuildInfo
 	// Auto update state
 	checkers         map[string]HealthChecker
 	backoffTimes     map[string]time.Duration
-	lastCheckAttempt map[string]time.Time
+	lastCheckAttempt map[string]time.Duration
+	// Checkers whose last failure was permanent, so it is logged once per failure streak
+	permanentFailures map[string]bool
+	// Monotonic deadlines until which a checker's expiry is suspended
+	expiryExtensions map[string]time.Duration
+	// Conditions of checkers registered with RegisterConditional
+	conditions map[string]func(*HealthAggregator) bool
+	// Checkers registered with CheckOptions.ManualUpdate
+	manualUpdate map[string]bool
+	// Checkers with a check currently in progress
+	running map[string]*runningCheck
+	// Auto-update timing per checker, see Stats
+	scheduling map[string]SchedulingStats
+	// Whether auto-update ticks are currently skipped
+	paused atomic.Bool
+	// Maintenance reason while in maintenance mode, nil otherwise
+	maintenance atomic.Pointer[string]
+	// Whether DrainFile currently exists
+	draining atomic.Bool
+	// Whether BeginShutdown was called
+	shuttingDown atomic.Bool
+	// Whether the checks passed for the stabilization period, then latched; the passing
+	// streak is owned by processUpdates
+	stabilized    atomic.Bool
+	passingStreak bool
+	passingSince  time.Duration
+	// Immutable copy of statuses, replaced on every change so readers need no lock
+	snapshot atomic.Pointer[map[string]*HealthStatus]
+	// Overall readiness last reported to OnReady/OnNotReady, owned by processUpdates
+	ready          bool
+	readyPending   bool
+	readySince     time.Duration
+	readinessTimer *time.Timer
+	// Status change callbacks held back by ChangeCallbackThrottle, owned by processUpdates
+	throttle callbackThrottle
+	// One-shot callbacks for the first time the service becomes ready
+	onceMu    sync.Mutex
+	onceReady []func()
+	everReady bool
+	// Streams notified of every applied update
+	subsMu      sync.Mutex
+	subscribers map[*subscriber]struct{}
 }
 
 // NewHealthAggregator creates a new health aggregator instance with optional configuration
@@ -124,176 +667,981 @@ func NewHealthAggregator(ctx context.Context, opts ...Option) *HealthAggregator
 	}
 
 	ctx, cancel := context.WithCancel(ctx)
-	return &HealthAggregator{
-		statuses:         make(map[string]*HealthStatus),
-		config:           config,
-		ctx:              ctx,
-		cancel:           cancel,
-		updateChannel:    make(chan *HealthStatus, config.UpdateBuffer),
-		checkers:         make(map[string]HealthChecker),
-		backoffTimes:     make(map[string]time.Duration),
-		lastCheckAttempt: make(map[string]time.Time),
+	ha := &HealthAggregator{
+		statuses:          make(map[string]*HealthStatus),
+		config:            config,
+		ctx:               ctx,
+		cancel:            cancel,
+		pending:           make(map[string]*HealthStatus),
+		pendingSlots:      make(chan struct{}, max(config.UpdateBuffer, 1)),
+		pendingNotify:     make(chan struct{}, 1),
+		checkers:          make(map[string]HealthChecker),
+		backoffTimes:      make(map[string]time.Duration),
+		lastCheckAttempt:  make(map[string]time.Duration),
+		permanentFailures: make(map[string]bool),
+		expiryExtensions:  make(map[string]time.Duration),
+		conditions:        make(map[string]func(*HealthAggregator) bool),
+		manualUpdate:      make(map[string]bool),
+		running:           make(map[string]*runningCheck),
+		scheduling:        make(map[string]SchedulingStats),
+		subscribers:       make(map[*subscriber]struct{}),
+	}
+	ha.publishSnapshot()
+	if config.AuditLog != nil {
+		ha.auditLog = newAsyncWriter(config.AuditLog, auditBufferSize)
 	}
+	if config.ResultRecorder != nil {
+		ha.recorder = newResultRecorder(config)
+	}
+	if config.AutoUpdateEnabled {
+		ha.validateBackoff()
+		ha.validateScheduler()
+	}
+	if config.StatsdAddr != "" {
+		statsd, err := newStatsdClient(config.StatsdAddr, config.StatsdPrefix)
+		if err != nil {
+			ha.logger().Warn("statsd reporting disabled", "addr", config.StatsdAddr, "error", err)
+		}
+		ha.statsd = statsd
+	}
+	ha.stabilized.Store(config.StabilizationPeriod <= 0)
+	if config.IncludeBuildInfo {
+		ha.buildInfo = readBuildInfo()
+	}
+	return ha
 }
 
 // Start begins processing health updates and auto-updates if enabled
 func (ha *HealthAggregator) Start() {
-	go ha.processUpdates()
+	ha.wg.Add(1)
+	go func() {
+		defer ha.wg.Done()
+		ha.processUpdates()
+	}()
+	if ha.auditLog != nil {
+		ha.wg.Add(1)
+		go func() {
+			defer ha.wg.Done()
+			ha.auditLog.run(ha.ctx)
+		}()
+	}
+	if ha.recorder != nil {
+		ha.wg.Add(1)
+		go func() {
+			defer ha.wg.Done()
+			ha.recorder.run(ha.ctx)
+		}()
+	}
 	if ha.config.AutoUpdateEnabled {
-		go ha.autoUpdate()
+		ha.wg.Add(1)
+		go func() {
+			defer ha.wg.Done()
+			ha.autoUpdate()
+		}()
+	}
+	if ha.config.SharedStore != nil && ha.config.SharedInterval > 0 {
+		ha.wg.Add(1)
+		go func() {
+			defer ha.wg.Done()
+			ha.shareState()
+		}()
+	}
+	if ha.config.DrainFile != "" {
+		ha.checkDrainFile()
+		ha.wg.Add(1)
+		go func() {
+			defer ha.wg.Done()
+			ha.watchDrainFile()
+		}()
+	}
+	if ha.config.HeartbeatInterval > 0 {
+		ha.wg.Add(1)
+		go func() {
+			defer ha.wg.Done()
+			ha.heartbeat()
+		}()
 	}
 }
 
-// Stop gracefully shuts down the health aggregator
+// Stop gracefully shuts down the health aggregator and waits for background
+// goroutines to exit, so no checks start after it returns. Auto-update checks
+// still running are not waited for, so a hung checker can't block Stop. It must
+// not be called from a status change callback.
 func (ha *HealthAggregator) Stop() {
 	ha.cancel()
+	ha.wg.Wait()
+	if ha.statsd != nil {
+		ha.statsd.close()
+	}
+}
+
+// Config returns a copy of the effective configuration. Callbacks and other live
+// references (writers, logger, clock, scheduler, shared store, response encoder) are
+// omitted, so the result is safe to log or serialize.
+func (ha *HealthAggregator) Config() Config {
+	config := *ha.config
+	config.ExpiryByPriority = maps.Clone(ha.config.ExpiryByPriority)
+	config.OnStatusChange = nil
+	config.ResultObservers = nil
+	config.BeforeCheck = nil
+	config.AfterCheck = nil
+	config.ResultInterceptor = nil
+	config.OnReady = nil
+	config.OnNotReady = nil
+	config.AuditLog = nil
+	config.ResultRecorder = nil
+	config.Clock = nil
+	config.Logger = nil
+	config.SharedStore = nil
+	config.Scheduler = nil
+	config.ResponseEncoder = nil
+	return config
+}
+
+// CheckOptions holds optional per-check registration settings
+type CheckOptions struct {
+	// Informational checks are run and reported, but never affect GetLiveness/GetReadiness
+	Informational bool
+	// Soft dependencies are optional: while failing they degrade readiness, reporting DEGRADED,
+	// instead of failing it
+	Soft bool
+	// Description is a human-readable explanation of the check, such as "Primary Postgres
+	// connection pool", shown in detailed responses and the dashboard
+	Description string
+	// Tags are free-form labels such as "external" or "region:us-east", for GetReadinessByTag
+	// and ListByTag
+	Tags []string
+	// NoExpiry checks keep their last result however old it is, for event-driven push
+	// checkers whose silence is expected; other checks still expire
+	NoExpiry bool
+	// ManualUpdate checks are never run by auto-update; their results come from UpdateHealth
+	// alone, e.g. push-based checkers watching a remote status
+	ManualUpdate bool
+}
+
+// affectsHealth reports whether the check counts towards overall liveness and readiness
+func (s *HealthStatus) affectsHealth() bool {
+	return !s.Informational && !s.Inactive
 }
 
 // RegisterHealthCheck adds a new health check to the aggregator
 func (ha *HealthAggregator) RegisterHealthCheck(checker HealthChecker, priority Priority) {
+	ha.RegisterHealthCheckWithOptions(checker, priority, CheckOptions{})
+}
+
+// RegisterHealthCheckWithOptions adds a new health check to the aggregator with per-check options
+func (ha *HealthAggregator) RegisterHealthCheckWithOptions(checker HealthChecker, priority Priority, opts CheckOptions) {
 	ha.mu.Lock()
 	defer ha.mu.Unlock()
 
 	name := checker.Name()
 	ha.checkers[name] = checker
-	ha.statuses[name] = &HealthStatus{
-		Checker:    checker,
-		Priority:   priority,
-		LastUpdate: time.Now(),
-	}
+	ha.setManualUpdate(name, opts.ManualUpdate)
+	ha.setStatus(name, ha.registeredStatus(checker, priority, opts))
 }
 
-// UpdateHealth sends a health update to the aggregator
-func (ha *HealthAggregator) UpdateHealth(checker HealthChecker, livenessErr, readinessErr error) {
-	ha.mu.RLock()
-	status, exists := ha.statuses[checker.Name()]
-	ha.mu.RUnlock()
-
-	if !exists {
-		return
+// setManualUpdate records whether auto-update skips the named checker. ha.mu must be held.
+func (ha *HealthAggregator) setManualUpdate(name string, manual bool) {
+	if manual {
+		ha.manualUpdate[name] = true
+	} else {
+		delete(ha.manualUpdate, name)
 	}
+}
 
-	ha.updateChannel <- &HealthStatus{
-		Checker:      checker,
-		Priority:     status.Priority,
-		Liveness:     livenessErr == nil,
-		Readiness:    readinessErr == nil,
-		LastUpdate:   time.Now(),
-		LivenessErr:  livenessErr,
-		ReadinessErr: readinessErr,
+// registeredStatus returns the unknown, not-yet-checked status of a newly registered checker
+func (ha *HealthAggregator) registeredStatus(checker HealthChecker, priority Priority, opts CheckOptions) *HealthStatus {
+	return &HealthStatus{
+		Checker:       checker,
+		Priority:      priority,
+		LastUpdate:    ha.config.Clock.Now(),
+		Informational: opts.Informational,
+		Description:   opts.Description,
+		Tags:          slices.Clone(opts.Tags),
+		Soft:          opts.Soft,
+		NoExpiry:      opts.NoExpiry,
+		updatedAt:     ha.config.Clock.Monotonic(),
 	}
 }
 
-// GetLiveness returns the overall liveness status based on priorities
-func (ha *HealthAggregator) GetLiveness() (bool, map[string]error) {
-	ha.mu.RLock()
-	defer ha.mu.RUnlock()
+// Registration describes a checker for ReplaceAll
+type Registration struct {
+	Checker  HealthChecker
+	Priority Priority
+	Options  CheckOptions
+	// Condition makes the check conditional as with RegisterConditional, nil means always run
+	Condition func(*HealthAggregator) bool
+}
 
-	errs := make(map[string]error)
-	now := time.Now()
+// ReplaceAll atomically replaces the registered checkers with regs, e.g. on a config reload.
+// Checkers whose name is already registered keep their last known status and backoff, with
+// the new checker, priority and options applied; others are added as not yet checked, and
+// registered checkers missing from regs are removed. No reader sees a partial set.
+func (ha *HealthAggregator) ReplaceAll(regs []Registration) {
+	var removed []string
+	defer func() { ha.notifyRemoved(removed) }()
+	ha.mu.Lock()
+	defer ha.mu.Unlock()
 
-	// Check each priority level in order
-	for _, priority := range []Priority{PriorityCritical, PriorityHigh, PriorityMedium, PriorityLow} {
-		for name, status := range ha.statuses {
-			if status.Priority != priority {
-				continue
-			}
+	keep := make(map[string]bool, len(regs))
+	for _, reg := range regs {
+		keep[reg.Checker.Name()] = true
+	}
+	for name := range ha.statuses {
+		if !keep[name] {
+			ha.removeCheck(name)
+			removed = append(removed, name)
+		}
+	}
 
-			// Check if the status has expired
-			if now.Sub(status.LastUpdate) > ha.config.ExpiryTime {
-				errs[name] = ErrHealthCheckExpired
-				return false, errs
-			}
+	for _, reg := range regs {
+		name := reg.Checker.Name()
+		ha.checkers[name] = reg.Checker
+		ha.setManualUpdate(name, reg.Options.ManualUpdate)
+		if reg.Condition != nil {
+			ha.conditions[name] = reg.Condition
+		} else {
+			delete(ha.conditions, name)
+		}
 
-			if !status.Liveness {
-				errs[name] = status.LivenessErr
-				return false, errs
-			}
+		status, ok := ha.statuses[name]
+		if !ok {
+			ha.statuses[name] = ha.registeredStatus(reg.Checker, reg.Priority, reg.Options)
+			continue
+		}
+		updated := *status
+		updated.Checker = reg.Checker
+		updated.Priority = reg.Priority
+		updated.Informational = reg.Options.Informational
+		updated.Description = reg.Options.Description
+		updated.Tags = slices.Clone(reg.Options.Tags)
+		updated.Soft = reg.Options.Soft
+		updated.NoExpiry = reg.Options.NoExpiry
+		if reg.Condition == nil {
+			updated.Inactive = false
 		}
+		ha.statuses[name] = &updated
 	}
-
-	return true, nil
+	ha.publishSnapshot()
 }
 
-// GetReadiness returns the overall readiness status based on priorities
-func (ha *HealthAggregator) GetReadiness() (bool, map[string]error) {
-	ha.mu.RLock()
-	defer ha.mu.RUnlock()
+// RegisterConditional adds a health check that auto-update only runs while condition holds,
+// e.g. a replication lag check that only makes sense while the database is reachable. While
+// the condition is false the check is skipped and marked Inactive, so it neither fails nor
+// expires. The condition is evaluated before each scheduled run without the aggregator lock,
+// so it may query the aggregator.
+func (ha *HealthAggregator) RegisterConditional(checker HealthChecker, priority Priority, condition func(*HealthAggregator) bool) {
+	ha.RegisterHealthCheck(checker, priority)
 
-	errs := make(map[string]error)
-	now := time.Now()
+	ha.mu.Lock()
+	defer ha.mu.Unlock()
+	ha.conditions[checker.Name()] = condition
+}
 
-	// Check each priority level in order
-	for _, priority := range []Priority{PriorityCritical, PriorityHigh, PriorityMedium, PriorityLow} {
-		for name, status := range ha.statuses {
-			if status.Priority != priority {
-				continue
-			}
+// UnregisterHealthCheck removes a health check and its auto-update state from the aggregator
+func (ha *HealthAggregator) UnregisterHealthCheck(name string) {
+	ha.mu.Lock()
+	_, registered := ha.statuses[name]
+	ha.removeCheck(name)
+	ha.publishSnapshot()
+	ha.mu.Unlock()
 
-			// Check if the status has expired
-			if now.Sub(status.LastUpdate) > ha.config.ExpiryTime {
-				errs[name] = ErrHealthCheckExpired
-				return false, errs
-			}
+	if registered {
+		ha.notifyRemoved([]string{name})
+	}
+}
 
-			if !status.Readiness {
-				errs[name] = status.ReadinessErr
-				return false, errs
-			}
+// notifyRemoved calls the result observers with a nil status for each removed check
+func (ha *HealthAggregator) notifyRemoved(names []string) {
+	for _, name := range names {
+		for _, observer := range ha.config.ResultObservers {
+			ha.runCallback("ResultObserver", func() { observer(name, nil) })
 		}
 	}
+}
 
-	return true, nil
+// removeCheck forgets a checker, its status and its auto-update state without publishing
+// a new snapshot. ha.mu must be held.
+func (ha *HealthAggregator) removeCheck(name string) {
+	delete(ha.checkers, name)
+	delete(ha.statuses, name)
+	delete(ha.backoffTimes, name)
+	delete(ha.lastCheckAttempt, name)
+	delete(ha.permanentFailures, name)
+	delete(ha.expiryExtensions, name)
+	delete(ha.conditions, name)
+	delete(ha.manualUpdate, name)
+	delete(ha.scheduling, name)
 }
 
-// GetOverallHealth returns both liveness and readiness status
-func (ha *HealthAggregator) GetOverallHealth() (liveness, readiness bool, livenessErrors, readinessErrors map[string]error) {
-	liveness, livenessErrors = ha.GetLiveness()
-	readiness, readinessErrors = ha.GetReadiness()
-	return
+// ResetStatus forgets the named checker's last known state and backoff, returning it to the
+// unknown, not-yet-checked state it had at registration so it must prove its health again
+func (ha *HealthAggregator) ResetStatus(name string) {
+	ha.mu.Lock()
+	defer ha.mu.Unlock()
+
+	status, ok := ha.statuses[name]
+	if !ok {
+		return
+	}
+	ha.setStatus(name, &HealthStatus{
+		Checker:       status.Checker,
+		Priority:      status.Priority,
+		LastUpdate:    ha.config.Clock.Now(),
+		Informational: status.Informational,
+		Description:   status.Description,
+		Tags:          status.Tags,
+		Soft:          status.Soft,
+		NoExpiry:      status.NoExpiry,
+		updatedAt:     ha.config.Clock.Monotonic(),
+	})
+	delete(ha.backoffTimes, name)
+	delete(ha.lastCheckAttempt, name)
+	delete(ha.permanentFailures, name)
 }
 
-// processUpdates handles incoming health updates
-func (ha *HealthAggregator) processUpdates() {
-	for {
-		select {
-		case <-ha.ctx.Done():
-			return
-		case status := <-ha.updateChannel:
-			ha.mu.Lock()
-			name := status.Checker.Name()
-			ha.statuses[name] = status
-			ha.mu.Unlock()
-
-			// Call status change callback if configured
-			if ha.config.OnStatusChange != nil {
-				ha.config.OnStatusChange(name, status)
-			}
-		}
+// ExtendExpiry suspends expiry for the named checker until the given deadline, e.g. during a
+// planned long operation in which it legitimately can't report. Normal expiry resumes afterwards.
+func (ha *HealthAggregator) ExtendExpiry(name string, until time.Time) {
+	ha.mu.Lock()
+	defer ha.mu.Unlock()
+
+	if _, registered := ha.checkers[name]; !registered {
+		return
 	}
+	// Convert the deadline to a monotonic reading so clock adjustments don't move it
+	remaining := until.Sub(ha.config.Clock.Now())
+	ha.expiryExtensions[name] = ha.config.Clock.Monotonic() + remaining
 }
 
-// autoUpdate performs automatic health checks for registered checkers
-func (ha *HealthAggregator) autoUpdate() {
-	// Initial delay
+// UpdateHealth sends a health update to the aggregator. Updates are applied asynchronously
+// and coalesced: if a checker's previous update hasn't been applied yet, it is replaced, so
+// only the latest state is applied and OnStatusChange may not see every intermediate one.
+// Updates for one checker are applied in the order they were sent; across checkers, in the
+// order their pending updates arrived. It only blocks while UpdateBuffer other checkers have
+// updates pending.
+func (ha *HealthAggregator) UpdateHealth(checker HealthChecker, livenessErr, readinessErr error) {
+	livenessErr, readinessErr = ha.intercept(checker.Name(), livenessErr, readinessErr)
+	ha.enqueueUpdate(checker, livenessErr, readinessErr, 0)
+}
+
+// TryUpdateHealth is UpdateHealth for push-based checkers that need feedback. It returns false
+// if the checker isn't registered, and ErrUpdateQueueFull instead of blocking when the update
+// buffer is full, or the context error once the aggregator has been stopped.
+func (ha *HealthAggregator) TryUpdateHealth(checker HealthChecker, livenessErr, readinessErr error) (bool, error) {
+	livenessErr, readinessErr = ha.intercept(checker.Name(), livenessErr, readinessErr)
+	update := ha.newUpdate(checker, livenessErr, readinessErr, 0)
+	if update == nil {
+		return false, nil
+	}
+
+	return true, ha.stageUpdate(update, false)
+}
+
+// enqueueUpdate queues a health update for processUpdates to apply and returns it, or nil
+// when the checker isn't registered
+func (ha *HealthAggregator) enqueueUpdate(checker HealthChecker, livenessErr, readinessErr error, duration time.Duration) *HealthStatus {
+	update := ha.newUpdate(checker, livenessErr, readinessErr, duration)
+	if update == nil {
+		return nil
+	}
+
+	_ = ha.stageUpdate(update, true)
+	return update
+}
+
+// stageUpdate makes update the checker's pending update, replacing any not yet applied, and
+// wakes up processUpdates. A checker without a pending update needs a free slot: without one
+// stageUpdate waits for it when block is set, and returns ErrUpdateQueueFull otherwise.
+func (ha *HealthAggregator) stageUpdate(update *HealthStatus, block bool) error {
+	if err := ha.ctx.Err(); err != nil {
+		return err
+	}
+	name := update.Checker.Name()
+
+	ha.pendingMu.Lock()
+	if _, ok := ha.pending[name]; ok {
+		ha.pending[name] = update
+		ha.pendingMu.Unlock()
+		return nil
+	}
+	ha.pendingMu.Unlock()
+
+	if block {
+		// Don't block forever once the aggregator has been stopped
+		select {
+		case ha.pendingSlots <- struct{}{}:
+		case <-ha.ctx.Done():
+			return ha.ctx.Err()
+		}
+	} else {
+		select {
+		case ha.pendingSlots <- struct{}{}:
+		default:
+			return ErrUpdateQueueFull
+		}
+	}
+
+	ha.pendingMu.Lock()
+	if _, ok := ha.pending[name]; ok {
+		// Another update for the checker was staged meanwhile and holds a slot already
+		<-ha.pendingSlots
+	} else {
+		ha.pendingOrder = append(ha.pendingOrder, name)
+	}
+	ha.pending[name] = update
+	ha.pendingMu.Unlock()
+
 	select {
-	case <-ha.ctx.Done():
+	case ha.pendingNotify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// takePending removes and returns the pending updates in order of arrival, freeing their slots
+func (ha *HealthAggregator) takePending() []*HealthStatus {
+	ha.pendingMu.Lock()
+	defer ha.pendingMu.Unlock()
+
+	updates := make([]*HealthStatus, 0, len(ha.pendingOrder))
+	for _, name := range ha.pendingOrder {
+		updates = append(updates, ha.pending[name])
+		delete(ha.pending, name)
+		<-ha.pendingSlots
+	}
+	ha.pendingOrder = ha.pendingOrder[:0]
+	return updates
+}
+
+// dropPending discards the checker's pending update, if any, freeing its slot
+func (ha *HealthAggregator) dropPending(name string) {
+	ha.pendingMu.Lock()
+	defer ha.pendingMu.Unlock()
+
+	if _, ok := ha.pending[name]; !ok {
 		return
-	case <-time.After(ha.config.InitialDelay):
-		// Perform initial checks immediately after delay
-		ha.mu.RLock()
-		checkers := make([]HealthChecker, 0, len(ha.checkers))
-		for _, checker := range ha.checkers {
-			checkers = append(checkers, checker)
+	}
+	delete(ha.pending, name)
+	ha.pendingOrder = slices.DeleteFunc(ha.pendingOrder, func(pending string) bool { return pending == name })
+	<-ha.pendingSlots
+}
+
+// newUpdate builds the status resulting from a check, or returns nil when the checker isn't registered
+func (ha *HealthAggregator) newUpdate(checker HealthChecker, livenessErr, readinessErr error, duration time.Duration) *HealthStatus {
+	ha.mu.RLock()
+	status, exists := ha.statuses[checker.Name()]
+	ha.mu.RUnlock()
+
+	if !exists {
+		return nil
+	}
+
+	// Start from the current status so registration settings carry over
+	update := *status
+	update.Checker = checker
+	update.Liveness = livenessErr == nil
+	update.Readiness = readinessErr == nil || isDegraded(readinessErr)
+	update.LastUpdate = ha.config.Clock.Now()
+	update.LivenessErr = livenessErr
+	update.ReadinessErr = readinessErr
+	update.Duration = duration
+	update.SkippedRuns = 0
+	update.Inactive = false
+	if reporter, ok := checkerAs[DurationReporter](checker); ok {
+		update.Duration = reporter.ReportedDuration()
+	}
+	if provider, ok := checkerAs[DetailProvider](checker); ok {
+		update.Details = maps.Clone(provider.Details())
+	}
+	update.updatedAt = ha.config.Clock.Monotonic()
+	update.checked = true
+	if !update.Readiness && (!status.checked || status.Readiness) {
+		update.unreadySince = update.updatedAt
+	}
+	switch {
+	case update.Liveness && update.Readiness:
+		update.FailingSince = time.Time{}
+		update.LastSuccess = update.LastUpdate
+	case update.FailingSince.IsZero():
+		update.FailingSince = update.LastUpdate
+	}
+	return &update
+}
+
+// GetLiveness returns the overall liveness status based on priorities
+func (ha *HealthAggregator) GetLiveness() (bool, map[string]error) {
+	return ha.aggregate(ha.statusLiveness, nil)
+}
+
+// GetReadiness returns the overall readiness status based on priorities
+func (ha *HealthAggregator) GetReadiness() (bool, map[string]error) {
+	if name, err := ha.readinessOverride(); err != nil {
+		return false, map[string]error{name: err}
+	}
+	return ha.aggregate(ha.statusReadiness, nil)
+}
+
+// GetReadinessContext runs every non-informational check now and reports readiness from the
+// fresh results, returning all failing checks. Checks still running when ctx is done report
+// ErrCheckCanceled, so a probe with a strict timeout gets a fast, partial answer. Checks
+// can't be interrupted: a canceled check keeps running and its result still refreshes the
+// stored status when it completes.
+func (ha *HealthAggregator) GetReadinessContext(ctx context.Context) (bool, map[string]error) {
+	if name, err := ha.readinessOverride(); err != nil {
+		return false, map[string]error{name: err}
+	}
+
+	ha.mu.RLock()
+	statuses := make(map[string]*HealthStatus, len(ha.statuses))
+	for name, status := range ha.statuses {
+		if status.affectsHealth() {
+			statuses[name] = status
+		}
+	}
+	ha.mu.RUnlock()
+
+	type result struct {
+		name string
+		err  error
+	}
+	results := make(chan result, len(statuses))
+	for name, status := range statuses {
+		go func(name string, checker HealthChecker) {
+			_, readinessErr := ha.runNow(ctx, name, checker)
+			results <- result{name: name, err: readinessErr}
+		}(name, status.Checker)
+	}
+
+	errs := make(map[string]error)
+	for pending := len(statuses); pending > 0; pending-- {
+		select {
+		case r := <-results:
+			if r.err == nil {
+				r.err = ha.staleDataError(statuses[r.name])
+			}
+			if r.err != nil && !isDegraded(r.err) && !statuses[r.name].Soft {
+				errs[r.name] = r.err
+			}
+			delete(statuses, r.name)
+		case <-ctx.Done():
+			for name := range statuses {
+				errs[name] = ErrCheckCanceled
+			}
+			return false, errs
+		}
+	}
+
+	if len(errs) > 0 {
+		return false, errs
+	}
+	return true, nil
+}
+
+// RunAllWithBudget runs every registered check now, in parallel, and returns the resulting
+// statuses of those that completed within budget (or before ctx is done). Unfinished checks
+// are reported not live nor ready with an error wrapping ErrCheckCanceled and the context
+// error, so a probe endpoint with a strict latency budget gets a bounded, partial answer.
+// As with GetReadinessContext, unfinished checks keep running and still store their result.
+func (ha *HealthAggregator) RunAllWithBudget(ctx context.Context, budget time.Duration) map[string]*HealthStatus {
+	ctx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+
+	ha.mu.RLock()
+	unfinished := maps.Clone(ha.statuses)
+	ha.mu.RUnlock()
+
+	results := make(chan *HealthStatus, len(unfinished))
+	for name, status := range unfinished {
+		go func(name string, checker HealthChecker) {
+			update, _ := ha.runNow(ctx, name, checker)
+			results <- update
+		}(name, status.Checker)
+	}
+
+	statuses := make(map[string]*HealthStatus, len(unfinished))
+	for pending := len(unfinished); pending > 0; pending-- {
+		select {
+		case update := <-results:
+			if update == nil {
+				// Unregistered while running
+				continue
+			}
+			name := update.Checker.Name()
+			statuses[name] = update
+			delete(unfinished, name)
+		case <-ctx.Done():
+			err := fmt.Errorf("%w: %w", ErrCheckCanceled, ctx.Err())
+			for name, status := range unfinished {
+				timedOut := *status
+				timedOut.Liveness, timedOut.Readiness = false, false
+				timedOut.LivenessErr, timedOut.ReadinessErr = err, err
+				statuses[name] = &timedOut
+			}
+			return statuses
 		}
-		ha.mu.RUnlock()
+	}
+	return statuses
+}
+
+// runNow runs a check outside of auto-update and stores its result, returning the resulting
+// status (nil if the checker was unregistered) and its readiness error. A ContextHealthChecker
+// gets ctx; its result isn't stored when it failed because ctx is done, as that says nothing
+// about the dependency.
+func (ha *HealthAggregator) runNow(ctx context.Context, name string, checker HealthChecker) (*HealthStatus, error) {
+	livenessErr, readinessErr, duration := ha.execute(ctx, name, checker)
+	if err := ctx.Err(); err != nil && (errors.Is(livenessErr, err) || errors.Is(readinessErr, err)) {
+		return nil, readinessErr
+	}
+	livenessErr, readinessErr = ha.intercept(name, livenessErr, readinessErr)
+	return ha.enqueueUpdate(checker, livenessErr, readinessErr, duration), readinessErr
+}
+
+// probeFunc reports whether a single status passes a probe, and why not
+type probeFunc func(name string, status *HealthStatus, now time.Duration) (bool, error)
+
+// aggregate evaluates a probe across all checks, or those include accepts when it isn't nil,
+// in priority order, stopping at the first failure
+func (ha *HealthAggregator) aggregate(probe probeFunc, include func(*HealthStatus) bool) (bool, map[string]error) {
+	ha.mu.RLock()
+	defer ha.mu.RUnlock()
+
+	errs := make(map[string]error)
+	now := ha.config.Clock.Monotonic()
+
+	// Check each priority level present in order, so any integer priority works
+	for _, priority := range ha.priorities() {
+		for name, status := range ha.statuses {
+			if status.Priority != priority || !status.affectsHealth() {
+				continue
+			}
+			if include != nil && !include(status) {
+				continue
+			}
+
+			if ok, err := probe(name, status, now); !ok {
+				errs[name] = err
+				return false, errs
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// priorities returns the distinct priorities of the registered checks, most critical first.
+// ha.mu must be held.
+func (ha *HealthAggregator) priorities() []Priority {
+	levels := make(map[Priority]struct{})
+	for _, status := range ha.statuses {
+		levels[status.Priority] = struct{}{}
+	}
+	return slices.Sorted(maps.Keys(levels))
+}
+
+// statusLiveness reports whether a single status is live
+func (ha *HealthAggregator) statusLiveness(name string, status *HealthStatus, now time.Duration) (bool, error) {
+	// Check if the status has expired
+	if err := ha.expiryError(name, status, now); err != nil {
+		return false, err
+	}
 
-		for _, checker := range checkers {
-			ha.checkHealth(checker)
+	if !status.Liveness {
+		return false, status.LivenessErr
+	}
+
+	if after := ha.config.LivenessFromReadiness; after > 0 && !status.Soft && status.checked && !status.Readiness {
+		if unready := now - status.unreadySince; unready >= after {
+			return false, fmt.Errorf("%w for %v: %w", ErrNotReadyTooLong, unready.Round(time.Second), status.ReadinessErr)
 		}
 	}
+	return true, nil
+}
+
+// statusReadiness reports whether a single status is ready. A ready status that only degrades
+// the service, including a failing soft dependency, also returns its degraded error.
+func (ha *HealthAggregator) statusReadiness(name string, status *HealthStatus, now time.Duration) (bool, error) {
+	ok, err := ha.hardReadiness(name, status, now)
+	switch {
+	case !ok && status.Soft && err != nil:
+		return true, Degraded(err)
+	case !ok && status.Soft:
+		return true, ErrDegraded
+	case ok && isDegraded(status.ReadinessErr):
+		return true, status.ReadinessErr
+	}
+	return ok, err
+}
+
+// hardReadiness reports whether a single status is ready, regardless of soft dependencies
+func (ha *HealthAggregator) hardReadiness(name string, status *HealthStatus, now time.Duration) (bool, error) {
+	// Check if the status has expired
+	if err := ha.expiryError(name, status, now); err != nil {
+		return false, err
+	}
+
+	if !status.Readiness {
+		return false, status.ReadinessErr
+	}
+
+	if err := ha.staleDataError(status); err != nil {
+		return false, err
+	}
+	return true, nil
+}
 
-	ticker := time.NewTicker(ha.config.CheckInterval)
+// GetOverallHealth returns both liveness and readiness status
+func (ha *HealthAggregator) GetOverallHealth() (liveness, readiness bool, livenessErrors, readinessErrors map[string]error) {
+	liveness, livenessErrors = ha.GetLiveness()
+	readiness, readinessErrors = ha.GetReadiness()
+	return
+}
+
+// Snapshot returns a point-in-time view of all check statuses without taking the aggregator
+// lock, so frequent readers such as metrics collectors never contend with updates. The map
+// and statuses are shared and must not be modified.
+func (ha *HealthAggregator) Snapshot() map[string]*HealthStatus {
+	return *ha.snapshot.Load()
+}
+
+// setStatus stores a check's status and publishes a new snapshot. ha.mu must be held.
+func (ha *HealthAggregator) setStatus(name string, status *HealthStatus) {
+	ha.statuses[name] = status
+	ha.publishSnapshot()
+}
+
+// publishSnapshot replaces the lock-free snapshot with a copy of the statuses. ha.mu must be held.
+func (ha *HealthAggregator) publishSnapshot() {
+	snapshot := maps.Clone(ha.statuses)
+	ha.snapshot.Store(&snapshot)
+}
+
+// FailingChecks returns a snapshot of the checks whose liveness or readiness
+// is currently failing or whose status has expired
+func (ha *HealthAggregator) FailingChecks() map[string]*HealthStatus {
+	ha.mu.RLock()
+	defer ha.mu.RUnlock()
+
+	failing := make(map[string]*HealthStatus)
+	now := ha.config.Clock.Monotonic()
+	for name, status := range ha.statuses {
+		if status.Inactive || status.Liveness && status.Readiness && ha.expiryError(name, status, now) == nil {
+			continue
+		}
+		snapshot := *status
+		failing[name] = &snapshot
+	}
+	return failing
+}
+
+// expiryError returns an ExpiredError if the status has not been updated within the expiry time.
+// ha.mu must be held.
+func (ha *HealthAggregator) expiryError(name string, status *HealthStatus, now time.Duration) error {
+	if status.NoExpiry {
+		return nil
+	}
+	age := now - status.updatedAt
+	if policy, ok := checkerAs[ExpiryPolicy](status.Checker); ok {
+		if !policy.IsExpired(status.LastUpdate, ha.config.Clock.Now()) {
+			return nil
+		}
+	} else if age <= ha.expiryTime(status.Priority) {
+		return nil
+	}
+	if !status.checked && age <= ha.config.RegistrationGrace {
+		return nil
+	}
+	if until, ok := ha.expiryExtensions[name]; ok && now < until {
+		return nil
+	}
+	return &ExpiredError{Name: name, Age: age}
+}
+
+// expiryTime returns the expiry time for the given priority, falling back to the global expiry time
+func (ha *HealthAggregator) expiryTime(priority Priority) time.Duration {
+	if expiry, ok := ha.config.ExpiryByPriority[priority]; ok {
+		return expiry
+	}
+	if n := ha.config.ExpiryMissedChecks; n > 0 {
+		return time.Duration(n) * ha.config.CheckInterval
+	}
+	return ha.config.ExpiryTime
+}
+
+// staleDataError returns an error if the checker reports data older than the configured MaxDataAge
+func (ha *HealthAggregator) staleDataError(status *HealthStatus) error {
+	if ha.config.MaxDataAge <= 0 {
+		return nil
+	}
+	reporter, ok := checkerAs[FreshnessReporter](status.Checker)
+	if !ok {
+		return nil
+	}
+	if age := reporter.DataAge(); age > ha.config.MaxDataAge {
+		return fmt.Errorf("%w: data is %s old (max %s)", ErrStaleData, age.Round(time.Millisecond), ha.config.MaxDataAge)
+	}
+	return nil
+}
+
+// readinessPollInterval is how often overall readiness is re-evaluated without updates,
+// so transitions caused by expiry are still reported
+const readinessPollInterval = time.Second
+
+// processUpdates handles incoming health updates
+func (ha *HealthAggregator) processUpdates() {
+	poll := time.NewTicker(readinessPollInterval)
+	defer poll.Stop()
+
+	ha.readinessTimer = time.NewTimer(ha.config.ReadinessDebounce)
+	ha.readinessTimer.Stop()
+	defer ha.readinessTimer.Stop()
+
+	ha.throttle = callbackThrottle{
+		timer:   time.NewTimer(0),
+		last:    make(map[string]time.Duration),
+		pending: make(map[string]*HealthStatus),
+	}
+	ha.throttle.timer.Stop()
+	defer ha.throttle.timer.Stop()
+
+	for {
+		select {
+		case <-ha.ctx.Done():
+			return
+		case <-poll.C:
+			ha.warnStuckChecks()
+			ha.trackStabilization()
+			if ha.tracksReadiness() {
+				ha.trackReadiness()
+			}
+		case <-ha.readinessTimer.C:
+			ha.trackReadiness()
+		case <-ha.throttle.timer.C:
+			ha.flushThrottled()
+		case <-ha.pendingNotify:
+			for _, status := range ha.takePending() {
+				ha.applyUpdate(status)
+			}
+		}
+	}
+}
+
+// applyUpdate stores an update and notifies the audit log, result recorder, statsd and callbacks
+func (ha *HealthAggregator) applyUpdate(status *HealthStatus) {
+	ha.mu.Lock()
+	name := status.Checker.Name()
+	if _, registered := ha.checkers[name]; !registered {
+		// The checker was unregistered while the update was queued
+		ha.mu.Unlock()
+		return
+	}
+	prev := ha.statuses[name]
+	ha.setStatus(name, status)
+	ha.mu.Unlock()
+
+	if ha.auditLog != nil {
+		ha.auditTransition(name, prev, status)
+	}
+	if ha.recorder != nil {
+		ha.recordResult(name, status)
+	}
+	if ha.statsd != nil {
+		ha.statsd.report(name, prev, status)
+	}
+
+	// Call status change callback if configured
+	if ha.config.OnStatusChange != nil {
+		ha.notifyStatusChange(name, status)
+	}
+	for _, observer := range ha.config.ResultObservers {
+		ha.runCallback("ResultObserver", func() { observer(name, status) })
+	}
+	ha.notifySubscribers(name)
+
+	ha.trackStabilization()
+	if ha.tracksReadiness() {
+		ha.trackReadiness()
+	}
+}
+
+// tracksReadiness reports whether overall readiness transitions need to be tracked
+func (ha *HealthAggregator) tracksReadiness() bool {
+	if ha.config.OnReady != nil || ha.config.OnNotReady != nil {
+		return true
+	}
+	ha.onceMu.Lock()
+	defer ha.onceMu.Unlock()
+	return len(ha.onceReady) > 0
+}
+
+// trackReadiness re-evaluates overall readiness and fires the transition callbacks
+// once a change has held for the debounce period. The service starts out not ready,
+// so OnNotReady only fires after it has been ready.
+func (ha *HealthAggregator) trackReadiness() {
+	ready, _ := ha.GetReadiness()
+	if ready == ha.ready {
+		// Any pending transition flapped back before it became stable
+		ha.readyPending = false
+		return
+	}
+
+	if debounce := ha.config.ReadinessDebounce; debounce > 0 {
+		now := ha.config.Clock.Monotonic()
+		if !ha.readyPending {
+			ha.readyPending = true
+			ha.readySince = now
+			ha.readinessTimer.Reset(debounce)
+			return
+		}
+		if now-ha.readySince < debounce {
+			return
+		}
+	}
+
+	ha.readyPending = false
+	ha.ready = ready
+
+	if ready {
+		ha.fireOnceReady()
+	}
+	if ready && ha.config.OnReady != nil {
+		ha.runCallback("OnReady", ha.config.OnReady)
+	}
+	if !ready && ha.config.OnNotReady != nil {
+		ha.runCallback("OnNotReady", ha.config.OnNotReady)
+	}
+}
+
+// OnceReady calls fn a single time, the first time overall readiness becomes true, e.g. to
+// log that the service is up. If the service has already been ready, fn is called right away.
+// fn runs on the update goroutine, so it must not block or call Stop.
+func (ha *HealthAggregator) OnceReady(fn func()) {
+	ha.onceMu.Lock()
+	if ha.everReady {
+		ha.onceMu.Unlock()
+		fn()
+		return
+	}
+	ha.onceReady = append(ha.onceReady, fn)
+	ha.onceMu.Unlock()
+}
+
+// fireOnceReady runs and discards the pending OnceReady callbacks
+func (ha *HealthAggregator) fireOnceReady() {
+	ha.onceMu.Lock()
+	ha.everReady = true
+	callbacks := ha.onceReady
+	ha.onceReady = nil
+	ha.onceMu.Unlock()
+
+	for _, fn := range callbacks {
+		ha.runCallback("OnceReady", fn)
+	}
+}
+
+// heartbeat logs a status summary every HeartbeatInterval
+func (ha *HealthAggregator) heartbeat() {
+	ticker := time.NewTicker(ha.config.HeartbeatInterval)
 	defer ticker.Stop()
 
 	for {
@@ -301,74 +1649,514 @@ func (ha *HealthAggregator) autoUpdate() {
 		case <-ha.ctx.Done():
 			return
 		case <-ticker.C:
+			live, _ := ha.GetLiveness()
+			ready, _ := ha.GetReadiness()
 			ha.mu.RLock()
-			checkers := make([]HealthChecker, 0, len(ha.checkers))
-			for _, checker := range ha.checkers {
-				checkers = append(checkers, checker)
-			}
+			checks := len(ha.statuses)
 			ha.mu.RUnlock()
+			ha.logger().Info("health heartbeat", "live", live, "ready", ready,
+				"checks", checks, "failing", len(ha.FailingChecks()))
+		}
+	}
+}
 
-			for _, checker := range checkers {
-				ha.checkHealth(checker)
-			}
+// autoUpdate performs automatic health checks for registered checkers
+func (ha *HealthAggregator) autoUpdate() {
+	// Initial delay
+	select {
+	case <-ha.ctx.Done():
+		return
+	case <-time.After(ha.config.InitialDelay):
+	}
+
+	scheduler := ha.config.Scheduler
+	if scheduler == nil {
+		scheduler = FixedInterval(ha.config.CheckInterval)
+	}
+
+	// Perform initial checks immediately after delay, then as scheduled
+	due := make(map[string]time.Time)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ha.ctx.Done():
+			return
+		case <-timer.C:
+			timer.Reset(ha.checkDue(scheduler, due))
 		}
 	}
 }
 
-// checkHealth performs a health check with backoff
-func (ha *HealthAggregator) checkHealth(checker HealthChecker) {
-	name := checker.Name()
-	now := time.Now()
+// checkDue runs every checker whose scheduled time has come, unless auto-update is paused,
+// and returns how long to wait for the next one. The wait is capped at CheckInterval so
+// newly registered checkers are picked up promptly.
+func (ha *HealthAggregator) checkDue(scheduler Scheduler, due map[string]time.Time) time.Duration {
+	wait := ha.config.CheckInterval
+	if ha.paused.Load() {
+		return wait
+	}
 
-	// Check if we should skip this check due to backoff
 	ha.mu.RLock()
-	backoff := ha.backoffTimes[name]
-	lastAttempt, exists := ha.lastCheckAttempt[name]
+	checkers := maps.Clone(ha.checkers)
+	maps.DeleteFunc(checkers, func(name string, _ HealthChecker) bool { return ha.manualUpdate[name] })
 	ha.mu.RUnlock()
 
-	if backoff > 0 && exists {
-		// Calculate time since last check attempt
-		timeSinceLastAttempt := now.Sub(lastAttempt)
-		if timeSinceLastAttempt < backoff {
-			// Skip this check as we're still in backoff period
-			return
+	// Forget checkers that were unregistered or switched to manual updates
+	for name := range due {
+		if _, ok := checkers[name]; !ok {
+			delete(due, name)
+		}
+	}
+
+	// Checkers due in this round share one reference time, so checkers on the same
+	// interval stay batched together
+	now := ha.config.Clock.Now()
+	var dueNow []HealthChecker
+	for name, checker := range checkers {
+		if next, ok := due[name]; !ok || !next.After(now) {
+			dueNow = append(dueNow, checker)
 		}
 	}
+	results := ha.runDue(dueNow, due)
+
+	for name := range checkers {
+		if status, ok := results[name]; ok {
+			wallNow := ha.config.Clock.Now()
+			due[name] = now.Add(scheduler.Next(name, status, wallNow).Sub(wallNow))
+		}
+		wait = min(wait, due[name].Sub(ha.config.Clock.Now()))
+	}
+	return max(wait, 0)
+}
+
+// runDue checks the given checkers concurrently, at most MaxConcurrency at a time, and returns
+// the resulting statuses by name. It waits for all of them, but no longer than CheckInterval:
+// checks still running then are left to finish on their own and are scheduled from their
+// stored status, the overlap protection skipping them until they do.
+func (ha *HealthAggregator) runDue(checkers []HealthChecker, due map[string]time.Time) map[string]*HealthStatus {
+	var (
+		mu      sync.Mutex
+		results = make(map[string]*HealthStatus, len(checkers))
+		wg      sync.WaitGroup
+		sem     chan struct{}
+	)
+	if ha.config.MaxConcurrency > 0 {
+		sem = make(chan struct{}, ha.config.MaxConcurrency)
+	}
+	for _, checker := range checkers {
+		name := checker.Name()
+		scheduled := due[name]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if sem != nil {
+				select {
+				case sem <- struct{}{}:
+					defer func() { <-sem }()
+				case <-ha.ctx.Done():
+					return
+				}
+			}
+			if ha.ctx.Err() != nil {
+				return
+			}
+			start := ha.config.Clock.Now()
+			status := ha.checkIfActive(checker)
+			if status != nil {
+				ha.recordRun(name, scheduled, start, ha.config.Clock.Now())
+			}
+			mu.Lock()
+			results[name] = status
+			mu.Unlock()
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	timer := time.NewTimer(ha.config.CheckInterval)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+	case <-ha.ctx.Done():
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	ha.mu.RLock()
+	defer ha.mu.RUnlock()
+	for _, checker := range checkers {
+		name := checker.Name()
+		if results[name] == nil {
+			// Skipped for backoff or overlap, or still running: schedule from the stored status
+			results[name] = ha.statuses[name]
+		}
+	}
+	return maps.Clone(results)
+}
+
+// PauseAutoUpdate stops background checks, e.g. during a maintenance window, while keeping
+// the aggregator running: UpdateHealth still works and all state is kept. Statuses still
+// expire while paused unless updated manually or covered by ExtendExpiry.
+func (ha *HealthAggregator) PauseAutoUpdate() {
+	ha.paused.Store(true)
+}
+
+// ResumeAutoUpdate restarts background checks from the next tick
+func (ha *HealthAggregator) ResumeAutoUpdate() {
+	ha.paused.Store(false)
+}
 
-	// Update last check attempt time before performing the check
+// validateBackoff warns about backoff settings that don't interact sensibly with the check interval
+func (ha *HealthAggregator) validateBackoff() {
+	if ha.config.BackoffFactor <= 1 {
+		ha.logger().Warn("backoff factor is not above 1, so backoff never grows beyond the check interval",
+			"factor", ha.config.BackoffFactor)
+	}
+	if ha.config.MaxBackoff < ha.config.CheckInterval {
+		ha.logger().Warn("max backoff is shorter than the check interval and is raised to it",
+			"max_backoff", ha.config.MaxBackoff, "check_interval", ha.config.CheckInterval)
+	}
+}
+
+// validateScheduler warns about StabilityInterval bounds that would check continuously and
+// clamps them: a min that isn't positive is raised to the check interval, and max to min
+func (ha *HealthAggregator) validateScheduler() {
+	s, ok := ha.config.Scheduler.(*stabilityInterval)
+	if !ok {
+		return
+	}
+	if s.min <= 0 {
+		ha.logger().Warn("stability interval min is not positive and is raised to the check interval",
+			"min", s.min, "check_interval", ha.config.CheckInterval)
+		s.min = ha.config.CheckInterval
+	}
+	if s.max < s.min {
+		ha.logger().Warn("stability interval max is shorter than min and is raised to it",
+			"max", s.max, "min", s.min)
+		s.max = s.min
+	}
+}
+
+// checkIfActive runs a checker unless its registration condition is false, in which case it
+// is marked inactive instead. It returns the resulting status, or nil when the check was skipped.
+func (ha *HealthAggregator) checkIfActive(checker HealthChecker) *HealthStatus {
+	name := checker.Name()
+	ha.mu.RLock()
+	condition := ha.conditions[name]
+	ha.mu.RUnlock()
+
+	if condition == nil || condition(ha) {
+		return ha.checkHealth(checker)
+	}
+
+	ha.mu.Lock()
+	defer ha.mu.Unlock()
+	status, ok := ha.statuses[name]
+	if !ok {
+		return nil
+	}
+	// An update staged before the condition turned false would mark the check active again
+	ha.dropPending(name)
+	inactive := *status
+	inactive.Inactive = true
+	inactive.LastUpdate = ha.config.Clock.Now()
+	inactive.updatedAt = ha.config.Clock.Monotonic()
+	ha.setStatus(name, &inactive)
+	return &inactive
+}
+
+// checkHealth performs a health check with backoff and overlap protection, returning the
+// resulting status, or nil when the check was skipped
+func (ha *HealthAggregator) checkHealth(checker HealthChecker) *HealthStatus {
+	name := checker.Name()
+	now := ha.config.Clock.Monotonic()
+
+	// Decide whether to skip this check and record the attempt under a single lock,
+	// so concurrent callers cannot both pass the gate.
 	ha.mu.Lock()
+	if ha.running[name] != nil {
+		// The previous check is slower than the interval: skip rather than stack another one
+		ha.recordSkippedRun(name)
+		ha.mu.Unlock()
+		return nil
+	}
+	if _, skip := ha.backoffRemaining(name, now); skip {
+		// Skip this check as we're still in backoff period
+		ha.mu.Unlock()
+		return nil
+	}
 	ha.lastCheckAttempt[name] = now
+	ha.running[name] = &runningCheck{since: now}
+	if status := ha.statuses[name]; status != nil && status.CircuitState == CircuitOpen {
+		// Trial probe after backing off
+		halfOpen := *status
+		halfOpen.CircuitState = CircuitHalfOpen
+		halfOpen.NextProbe = time.Time{}
+		ha.setStatus(name, &halfOpen)
+	}
 	ha.mu.Unlock()
 
+	defer func() {
+		ha.mu.Lock()
+		delete(ha.running, name)
+		ha.mu.Unlock()
+	}()
+
 	// Perform health checks
-	livenessErr := checker.CheckLiveness()
-	readinessErr := checker.CheckReadiness()
+	livenessErr, readinessErr, duration := ha.execute(ha.ctx, name, checker)
+	livenessErr, readinessErr = ha.intercept(name, livenessErr, readinessErr)
 
-	// Update backoff time based on check results
+	// Update backoff time based on check results. The current backoff is re-read
+	// here because it may have changed while the checks were running.
 	ha.mu.Lock()
-	if livenessErr != nil || readinessErr != nil {
+	if _, registered := ha.checkers[name]; !registered {
+		ha.mu.Unlock()
+		return nil
+	}
+	backoff := ha.backoffTimes[name]
+	if permanent := isPermanent(livenessErr) || isPermanent(readinessErr); permanent {
+		// Retrying sooner won't help a permanent failure: go straight to the max backoff
+		backoff = max(ha.config.MaxBackoff, ha.config.CheckInterval)
+		ha.backoffTimes[name] = backoff
+		if !ha.permanentFailures[name] {
+			ha.permanentFailures[name] = true
+			ha.logger().Error("health check failed permanently, retrying at max backoff",
+				"checker", name, "liveness_err", livenessErr, "readiness_err", readinessErr, "backoff", backoff)
+		}
+	} else if livenessErr != nil || readinessErr != nil {
+		delete(ha.permanentFailures, name)
 		// Increase backoff time
 		if backoff == 0 {
 			// Start with check interval as initial backoff
-			backoff = ha.config.CheckInterval / 2
+			backoff = ha.config.CheckInterval
 		} else {
 			// Exponential backoff
 			backoff = time.Duration(float64(backoff) * ha.config.BackoffFactor)
 		}
-		// Cap at max backoff
-		if backoff > ha.config.MaxBackoff {
-			backoff = ha.config.MaxBackoff
-		}
+		// Cap at max backoff, but never below the check interval, the shortest meaningful skip
+		backoff = max(min(backoff, ha.config.MaxBackoff), ha.config.CheckInterval)
 		ha.backoffTimes[name] = backoff
 	} else {
 		// Reset backoff on success
+		backoff = 0
 		ha.backoffTimes[name] = 0
+		delete(ha.permanentFailures, name)
+	}
+	if probe := ha.config.RecoveryProbeInterval; probe > 0 && backoff > 0 {
+		backoff = min(backoff, probe)
 	}
 	ha.mu.Unlock()
 
 	// Send update
-	ha.UpdateHealth(checker, livenessErr, readinessErr)
+	update := ha.newUpdate(checker, livenessErr, readinessErr, duration)
+	if update == nil {
+		return nil
+	}
+	update.CircuitState, update.NextProbe = CircuitClosed, time.Time{}
+	if backoff > 0 {
+		update.CircuitState = CircuitOpen
+		update.NextProbe = update.LastUpdate.Add(backoff)
+	}
+	_ = ha.stageUpdate(update, true)
+	return update
+}
+
+// runningCheck is a check currently in progress
+type runningCheck struct {
+	since  time.Duration
+	warned bool
+}
+
+// RunningChecks returns the names of the checks currently in progress, sorted
+func (ha *HealthAggregator) RunningChecks() []string {
+	ha.mu.RLock()
+	defer ha.mu.RUnlock()
+
+	names := make([]string, 0, len(ha.running))
+	for name := range ha.running {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// warnStuckChecks logs a warning, once per run, for each check running longer than the
+// stuck check threshold. Such a check most likely hangs and leaks its goroutine.
+func (ha *HealthAggregator) warnStuckChecks() {
+	threshold := ha.stuckCheckThreshold()
+	now := ha.config.Clock.Monotonic()
+
+	ha.mu.Lock()
+	defer ha.mu.Unlock()
+	for name, check := range ha.running {
+		if check.warned || now-check.since < threshold {
+			continue
+		}
+		check.warned = true
+		ha.logger().Warn("health check appears stuck", "check", name, "running", (now - check.since).Round(time.Millisecond))
+	}
+}
+
+// stuckCheckThreshold returns the configured StuckCheckThreshold or its default: three times
+// the CheckTimeout when set, otherwise three times the CheckInterval
+func (ha *HealthAggregator) stuckCheckThreshold() time.Duration {
+	switch {
+	case ha.config.StuckCheckThreshold > 0:
+		return ha.config.StuckCheckThreshold
+	case ha.config.CheckTimeout > 0:
+		return 3 * ha.config.CheckTimeout
+	default:
+		return 3 * ha.config.CheckInterval
+	}
+}
+
+// logger returns the configured logger or the default one
+func (ha *HealthAggregator) logger() *slog.Logger {
+	if ha.config.Logger != nil {
+		return ha.config.Logger
+	}
+	return slog.Default()
+}
+
+// recordSkippedRun counts a check skipped because the previous one was still running.
+// ha.mu must be held.
+func (ha *HealthAggregator) recordSkippedRun(name string) {
+	status, ok := ha.statuses[name]
+	if !ok {
+		return
+	}
+	// Statuses are replaced rather than mutated, as readers may hold the old pointer
+	skipped := *status
+	skipped.SkippedRuns++
+	ha.setStatus(name, &skipped)
+}
+
+// execute runs the checker's probes between the BeforeCheck and AfterCheck hooks, returning
+// the raw results and how long they took
+func (ha *HealthAggregator) execute(ctx context.Context, name string, checker HealthChecker) (livenessErr, readinessErr error, duration time.Duration) {
+	if hook := ha.config.BeforeCheck; hook != nil {
+		ha.recoverCallback("BeforeCheck", func() { hook(name) })
+	}
+	start := ha.config.Clock.Monotonic()
+	livenessErr, readinessErr = ha.runProbes(ctx, checker)
+	duration = ha.config.Clock.Monotonic() - start
+	if hook := ha.config.AfterCheck; hook != nil {
+		ha.recoverCallback("AfterCheck", func() { hook(name, livenessErr, readinessErr, duration) })
+	}
+	return livenessErr, readinessErr, duration
+}
+
+// runProbes runs the checker's liveness and readiness checks, in parallel if configured,
+// passing ctx to a ContextHealthChecker
+func (ha *HealthAggregator) runProbes(ctx context.Context, checker HealthChecker) (livenessErr, readinessErr error) {
+	liveness, readiness := checker.CheckLiveness, checker.CheckReadiness
+	if contextChecker, ok := checkerAs[ContextHealthChecker](checker); ok {
+		liveness = func() error {
+			ctx, cancel := ha.probeContext(ctx)
+			defer cancel()
+			return contextChecker.CheckLivenessContext(ctx)
+		}
+		readiness = func() error {
+			ctx, cancel := ha.probeContext(ctx)
+			defer cancel()
+			return contextChecker.CheckReadinessContext(ctx)
+		}
+	}
+	if !ha.config.ConcurrentProbes {
+		return ha.callProbe(liveness), ha.callProbe(readiness)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		livenessErr = ha.callProbe(liveness)
+	}()
+	readinessErr = ha.callProbe(readiness)
+	wg.Wait()
+	return livenessErr, readinessErr
+}
+
+// probeContext returns the context of a ContextHealthChecker call: ctx with the check
+// timeout as deadline
+func (ha *HealthAggregator) probeContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if timeout := ha.config.CheckTimeout; timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// callProbe calls a liveness or readiness check, giving up with ErrHealthCheckTimeout once
+// it exceeds the check timeout
+func (ha *HealthAggregator) callProbe(probe func() error) error {
+	timeout := ha.config.CheckTimeout
+	if timeout <= 0 {
+		return probe()
+	}
+
+	// Buffered so an abandoned call can still complete and exit
+	result := make(chan error, 1)
+	go func() {
+		result <- probe()
+	}()
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("%w after %s", ErrHealthCheckTimeout, timeout)
+	}
+}
+
+// intercept passes check results through the configured result interceptor, if any. If the
+// interceptor panics, the results are returned as they are.
+func (ha *HealthAggregator) intercept(name string, livenessErr, readinessErr error) (error, error) {
+	interceptor := ha.config.ResultInterceptor
+	if interceptor == nil {
+		return livenessErr, readinessErr
+	}
+	interceptedLiveness, interceptedReadiness := livenessErr, readinessErr
+	ha.recoverCallback("ResultInterceptor", func() {
+		interceptedLiveness, interceptedReadiness = interceptor(name, livenessErr, readinessErr)
+	})
+	return interceptedLiveness, interceptedReadiness
 }
 
 // ErrHealthCheckExpired is returned when a health check has not been updated within the expiry time
 var ErrHealthCheckExpired = errors.New("health check has expired")
+
+// ErrHealthCheckTimeout is returned for a check call that exceeded the check timeout
+var ErrHealthCheckTimeout = errors.New("health check timed out")
+
+// ErrUpdateQueueFull is returned by TryUpdateHealth when the update buffer is full
+var ErrUpdateQueueFull = errors.New("health update queue is full")
+
+// ErrCheckCanceled is reported by GetReadinessContext for checks that didn't finish before its context was done
+var ErrCheckCanceled = errors.New("health check canceled")
+
+// ErrNotReadyTooLong fails liveness of a check whose readiness failed for longer than LivenessFromReadiness
+var ErrNotReadyTooLong = errors.New("not ready for too long")
+
+// ErrStaleData is returned when a FreshnessReporter's data is older than the configured MaxDataAge
+var ErrStaleData = errors.New("health check data is stale")
+
+// ExpiredError describes which health check expired and how long ago it was last updated.
+// It wraps ErrHealthCheckExpired, so errors.Is(err, ErrHealthCheckExpired) still holds.
+type ExpiredError struct {
+	Name string
+	Age  time.Duration
+}
+
+// Error implements the error interface
+func (e *ExpiredError) Error() string {
+	return fmt.Sprintf("%s: %s (last updated %s ago)", ErrHealthCheckExpired, e.Name, e.Age.Round(time.Millisecond))
+}
+
+// Unwrap returns ErrHealthCheckExpired
+func (e *ExpiredError) Unwrap() error {
+	return ErrHealthCheckExpired
+}
@@ -2,7 +2,15 @@ package gopulse
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -12,7 +20,7 @@ type mockHealthChecker struct {
 	name         string
 	livenessErr  error
 	readinessErr error
-	checkCount   int
+	checkCount   atomic.Int32
 	priority     Priority
 }
 
@@ -21,12 +29,12 @@ func (m *mockHealthChecker) Name() string {
 }
 
 func (m *mockHealthChecker) CheckLiveness() error {
-	m.checkCount++
+	m.checkCount.Add(1)
 	return m.livenessErr
 }
 
 func (m *mockHealthChecker) CheckReadiness() error {
-	m.checkCount++
+	m.checkCount.Add(1)
 	return m.readinessErr
 }
 
@@ -181,6 +189,20 @@ func TestExpiry(t *testing.T) {
 	if healthy || len(errs) == 0 {
 		t.Error("Expected checker to be expired")
 	}
+	if !errors.Is(errs["test"], ErrHealthCheckExpired) {
+		t.Errorf("Expected error to wrap ErrHealthCheckExpired, got %v", errs["test"])
+	}
+
+	var expiredErr *ExpiredError
+	if !errors.As(errs["test"], &expiredErr) {
+		t.Fatalf("Expected *ExpiredError, got %T", errs["test"])
+	}
+	if expiredErr.Name != "test" {
+		t.Errorf("Expected expired error for %q, got %q", "test", expiredErr.Name)
+	}
+	if expiredErr.Age <= 100*time.Millisecond {
+		t.Errorf("Expected age above expiry time, got %v", expiredErr.Age)
+	}
 }
 
 func TestPriorityOrder(t *testing.T) {
@@ -213,13 +235,48 @@ func TestPriorityOrder(t *testing.T) {
 	}
 }
 
+func TestCustomPriorityOrder(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	// Priorities outside the named levels, more and less critical than them
+	vital := &mockHealthChecker{name: "vital"}
+	trivial := &mockHealthChecker{name: "trivial"}
+	medium := &mockHealthChecker{name: "medium"}
+	ha.RegisterHealthCheck(trivial, PriorityLow+10)
+	ha.RegisterHealthCheck(medium, PriorityMedium)
+	ha.RegisterHealthCheck(vital, PriorityCritical-1)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(vital, nil, nil)
+	ha.UpdateHealth(medium, nil, nil)
+	ha.UpdateHealth(trivial, nil, errors.New("trivial error"))
+	time.Sleep(50 * time.Millisecond)
+	if _, errs := ha.GetReadiness(); len(errs) != 1 || errs["trivial"] == nil {
+		t.Errorf("Expected a custom low priority to be checked, got %v", errs)
+	}
+
+	ha.UpdateHealth(medium, nil, errors.New("medium error"))
+	ha.UpdateHealth(vital, nil, errors.New("vital error"))
+	time.Sleep(50 * time.Millisecond)
+	if _, errs := ha.GetReadiness(); len(errs) != 1 || errs["vital"] == nil {
+		t.Errorf("Expected the most critical priority to be returned first, got %v", errs)
+	}
+	if score := ha.Score(); score != 0 {
+		t.Errorf("Expected score 0 with every check failing, got %v", score)
+	}
+}
+
 func TestStatusChangeCallback(t *testing.T) {
 	ctx := context.Background()
+	var mu sync.Mutex
 	var callbackCalled bool
 	var lastStatus *HealthStatus
 
 	ha := NewHealthAggregator(ctx,
 		WithStatusChangeCallback(func(name string, status *HealthStatus) {
+			mu.Lock()
+			defer mu.Unlock()
 			callbackCalled = true
 			lastStatus = status
 		}),
@@ -233,6 +290,8 @@ func TestStatusChangeCallback(t *testing.T) {
 	ha.UpdateHealth(checker, nil, nil)
 	time.Sleep(100 * time.Millisecond)
 
+	mu.Lock()
+	defer mu.Unlock()
 	if !callbackCalled {
 		t.Error("Expected status change callback to be called")
 	}
@@ -290,8 +349,8 @@ func TestAutoUpdate(t *testing.T) {
 	time.Sleep(200 * time.Millisecond)
 
 	// Verify that checks were performed
-	if checker.checkCount < 2 {
-		t.Errorf("Expected at least 2 checks, got %d", checker.checkCount)
+	if checker.checkCount.Load() < 2 {
+		t.Errorf("Expected at least 2 checks, got %d", checker.checkCount.Load())
 	}
 
 	// Verify that status was updated
@@ -327,8 +386,8 @@ func TestAutoUpdateBackoff(t *testing.T) {
 	ha.Start()
 	defer ha.Stop()
 
-	// Wait for initial check and first backoff period
-	time.Sleep(initialBackoff + 10*time.Millisecond)
+	// Wait for the initial check
+	time.Sleep(10 * time.Millisecond)
 
 	// Verify initial backoff was applied
 	ha.mu.RLock()
@@ -339,8 +398,8 @@ func TestAutoUpdateBackoff(t *testing.T) {
 		t.Errorf("Expected initial backoff of %v, got %v", initialBackoff, backoff)
 	}
 
-	// Wait for second backoff period
-	time.Sleep(time.Duration(float64(initialBackoff)*backoffFactor) + 10*time.Millisecond)
+	// A backoff of one interval doesn't skip the next tick
+	time.Sleep(checkInterval)
 
 	// Verify backoff increased
 	ha.mu.RLock()
@@ -352,13 +411,172 @@ func TestAutoUpdateBackoff(t *testing.T) {
 		t.Errorf("Expected backoff of %v, got %v", expectedBackoff, backoff)
 	}
 
-	// Verify check count is reasonable
-	// Should have at least 2 checks (initial + after first backoff)
-	// and at most 3 checks (if timing allows)
+	// The doubled backoff skips the following tick
 	// Note: Each check calls both CheckLiveness and CheckReadiness, so checkCount is doubled
-	actualChecks := checker.checkCount / 2
-	if actualChecks < 2 || actualChecks > 3 {
-		t.Errorf("Expected 2-3 checks, got %d", actualChecks)
+	time.Sleep(checkInterval + 20*time.Millisecond)
+	if actualChecks := checker.checkCount.Load() / 2; actualChecks != 2 {
+		t.Errorf("Expected 2 checks, got %d", actualChecks)
+	}
+}
+
+func TestBackoffClampedToCheckInterval(t *testing.T) {
+	ctx := context.Background()
+	checkInterval := time.Hour
+
+	for _, tc := range []struct {
+		name       string
+		maxBackoff time.Duration
+		factor     float64
+		warning    string
+	}{
+		{"factor one", 2 * time.Hour, 1.0, "backoff factor is not above 1"},
+		{"max below interval", time.Minute, 2.0, "max backoff is shorter than the check interval"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var logs syncBuffer
+			ha := NewHealthAggregator(ctx,
+				WithAutoUpdate(checkInterval),
+				WithBackoff(tc.maxBackoff, tc.factor),
+				WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+			)
+			if !strings.Contains(logs.String(), tc.warning) {
+				t.Errorf("Expected warning %q, got %q", tc.warning, logs.String())
+			}
+
+			checker := &mockHealthChecker{name: "test", readinessErr: errors.New("down")}
+			ha.RegisterHealthCheck(checker, PriorityHigh)
+			for i := 0; i < 3; i++ {
+				ha.mu.Lock()
+				delete(ha.lastCheckAttempt, checker.name)
+				ha.mu.Unlock()
+				ha.checkHealth(checker)
+
+				ha.mu.RLock()
+				backoff := ha.backoffTimes[checker.name]
+				ha.mu.RUnlock()
+				if backoff != checkInterval {
+					t.Errorf("Check %d: expected backoff clamped to %v, got %v", i, checkInterval, backoff)
+				}
+			}
+		})
+	}
+}
+
+// temporaryError is a check error declaring whether it is transient, like net.Error
+type temporaryError bool
+
+func (e temporaryError) Error() string   { return "temporary error" }
+func (e temporaryError) Temporary() bool { return bool(e) }
+
+func TestPermanentFailureBackoff(t *testing.T) {
+	ctx := context.Background()
+	checkInterval := time.Hour
+	maxBackoff := 8 * time.Hour
+
+	var logs syncBuffer
+	ha := NewHealthAggregator(ctx,
+		WithAutoUpdate(checkInterval),
+		WithBackoff(maxBackoff, 2.0),
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+	)
+	checker := &mockHealthChecker{name: "test"}
+	ha.RegisterHealthCheck(checker, PriorityHigh)
+
+	check := func(err error) time.Duration {
+		t.Helper()
+		checker.readinessErr = err
+		ha.mu.Lock()
+		delete(ha.lastCheckAttempt, checker.name)
+		ha.mu.Unlock()
+		ha.checkHealth(checker)
+
+		ha.mu.RLock()
+		defer ha.mu.RUnlock()
+		return ha.backoffTimes[checker.name]
+	}
+
+	if backoff := check(temporaryError(true)); backoff != checkInterval {
+		t.Errorf("Expected temporary error to start backoff at %v, got %v", checkInterval, backoff)
+	}
+	for i := 0; i < 2; i++ {
+		if backoff := check(Permanent(errors.New("bad credentials"))); backoff != maxBackoff {
+			t.Errorf("Expected permanent error to jump to %v, got %v", maxBackoff, backoff)
+		}
+	}
+	if backoff := check(Permanent(errors.New("bad credentials"))); backoff != maxBackoff {
+		t.Errorf("Expected permanent error to stay at %v, got %v", maxBackoff, backoff)
+	}
+	if n := strings.Count(logs.String(), "failed permanently"); n != 1 {
+		t.Errorf("Expected permanent failure to be logged once, got %d: %q", n, logs.String())
+	}
+
+	if backoff := check(nil); backoff != 0 {
+		t.Errorf("Expected success to reset backoff, got %v", backoff)
+	}
+	check(Permanent(errors.New("bad credentials")))
+	if n := strings.Count(logs.String(), "failed permanently"); n != 2 {
+		t.Errorf("Expected a new failure streak to be logged again, got %d", n)
+	}
+}
+
+func TestRefusedConnectionIsTransient(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	_, dialErr := net.Dial("tcp", addr)
+	if dialErr == nil {
+		t.Skip("connection unexpectedly accepted")
+	}
+
+	checkInterval := time.Hour
+	ha := NewHealthAggregator(context.Background(),
+		WithAutoUpdate(checkInterval),
+		WithBackoff(8*time.Hour, 2.0),
+	)
+	checker := &mockHealthChecker{name: "db", readinessErr: dialErr}
+	ha.RegisterHealthCheck(checker, PriorityHigh)
+	ha.checkHealth(checker)
+
+	// A refused connection reports Temporary() == false, but must back off normally
+	ha.mu.RLock()
+	defer ha.mu.RUnlock()
+	if backoff := ha.backoffTimes["db"]; backoff != checkInterval {
+		t.Errorf("Expected backoff to start at %v, got %v", checkInterval, backoff)
+	}
+}
+
+func TestRecoveryProbeInterval(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{}
+	ha := NewHealthAggregator(ctx,
+		WithClock(clock),
+		WithAutoUpdate(time.Minute),
+		WithBackoff(time.Hour, 2.0),
+		WithRecoveryProbeInterval(5*time.Minute),
+	)
+	checker := &mockHealthChecker{name: "test", readinessErr: Permanent(errors.New("bad credentials"))}
+	ha.RegisterHealthCheck(checker, PriorityHigh)
+
+	if ha.checkHealth(checker) == nil {
+		t.Fatal("Expected the first check to run")
+	}
+	clock.Advance(0, 4*time.Minute)
+	if ha.checkHealth(checker) != nil {
+		t.Error("Expected the check to be skipped before the recovery probe interval")
+	}
+	clock.Advance(0, time.Minute)
+	if ha.checkHealth(checker) == nil {
+		t.Error("Expected a recovery probe despite the hour of backoff")
+	}
+
+	ha.mu.RLock()
+	defer ha.mu.RUnlock()
+	if backoff := ha.backoffTimes[checker.name]; backoff != time.Hour {
+		t.Errorf("Expected the backoff to stay at max, got %v", backoff)
 	}
 }
 
@@ -378,15 +596,15 @@ func TestAutoUpdateInitialDelay(t *testing.T) {
 	time.Sleep(50 * time.Millisecond)
 
 	// Verify no checks were performed
-	if checker.checkCount > 0 {
-		t.Errorf("Expected no checks before initial delay, got %d", checker.checkCount)
+	if checker.checkCount.Load() > 0 {
+		t.Errorf("Expected no checks before initial delay, got %d", checker.checkCount.Load())
 	}
 
 	// Wait past initial delay
 	time.Sleep(100 * time.Millisecond)
 
 	// Verify checks were performed
-	if checker.checkCount == 0 {
+	if checker.checkCount.Load() == 0 {
 		t.Error("Expected checks to be performed after initial delay")
 	}
 }
@@ -416,12 +634,39 @@ func TestAutoUpdateMultipleCheckers(t *testing.T) {
 
 	// Verify all checkers were checked
 	for _, checker := range checkers {
-		if checker.checkCount < 2 {
-			t.Errorf("Expected at least 2 checks for %s, got %d", checker.name, checker.checkCount)
+		if checker.checkCount.Load() < 2 {
+			t.Errorf("Expected at least 2 checks for %s, got %d", checker.name, checker.checkCount.Load())
 		}
 	}
 }
 
+func TestManualUpdateCheck(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx,
+		WithAutoUpdate(10*time.Millisecond),
+		WithInitialDelay(0),
+	)
+	polled := &mockHealthChecker{name: "polled"}
+	pushed := &mockHealthChecker{name: "pushed"}
+	ha.RegisterHealthCheck(polled, PriorityCritical)
+	ha.RegisterHealthCheckWithOptions(pushed, PriorityCritical, CheckOptions{ManualUpdate: true})
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(pushed, nil, nil)
+	time.Sleep(100 * time.Millisecond)
+
+	if polled.checkCount.Load() == 0 {
+		t.Error("Expected the polled checker to run")
+	}
+	if count := pushed.checkCount.Load(); count != 0 {
+		t.Errorf("Expected auto-update to skip the manual checker, got %d checks", count)
+	}
+	if status := ha.Snapshot()["pushed"]; !status.Readiness {
+		t.Errorf("Expected the pushed result to be kept, got %+v", status)
+	}
+}
+
 func TestAutoUpdateStop(t *testing.T) {
 	ctx := context.Background()
 	ha := NewHealthAggregator(ctx,
@@ -435,16 +680,1506 @@ func TestAutoUpdateStop(t *testing.T) {
 
 	// Wait for some checks
 	time.Sleep(100 * time.Millisecond)
-	initialCount := checker.checkCount
 
-	// Stop the aggregator
+	// Stop the aggregator; it waits for any in-flight check to finish
 	ha.Stop()
+	initialCount := checker.checkCount.Load()
 
 	// Wait for potential additional checks
 	time.Sleep(100 * time.Millisecond)
 
 	// Verify no additional checks were performed
-	if checker.checkCount != initialCount {
-		t.Errorf("Expected no additional checks after stop, got %d more", checker.checkCount-initialCount)
+	if checker.checkCount.Load() != initialCount {
+		t.Errorf("Expected no additional checks after stop, got %d more", checker.checkCount.Load()-initialCount)
+	}
+}
+
+func TestUnregisterHealthCheck(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	checker := &mockHealthChecker{name: "test", priority: PriorityCritical}
+
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(checker, errors.New("down"), errors.New("down"))
+	time.Sleep(100 * time.Millisecond)
+
+	ha.UnregisterHealthCheck(checker.name)
+
+	healthy, errs := ha.GetLiveness()
+	if !healthy || len(errs) > 0 {
+		t.Error("Expected unregistered checker to no longer affect liveness")
+	}
+
+	// Updates for an unregistered checker must not re-register it
+	ha.UpdateHealth(checker, nil, nil)
+	time.Sleep(100 * time.Millisecond)
+
+	ha.mu.RLock()
+	_, exists := ha.statuses[checker.name]
+	ha.mu.RUnlock()
+	if exists {
+		t.Error("Expected checker to stay unregistered after update")
+	}
+}
+
+func TestReplaceAll(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	db := &mockHealthChecker{name: "db"}
+	legacy := &mockHealthChecker{name: "legacy"}
+	ha.RegisterHealthCheck(db, PriorityCritical)
+	ha.RegisterHealthCheck(legacy, PriorityHigh)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(db, nil, nil)
+	ha.UpdateHealth(legacy, errors.New("down"), errors.New("down"))
+	time.Sleep(100 * time.Millisecond)
+
+	newDB := &mockHealthChecker{name: "db"}
+	cache := &mockHealthChecker{name: "cache"}
+	ha.ReplaceAll([]Registration{
+		{Checker: newDB, Priority: PriorityHigh},
+		{Checker: cache, Priority: PriorityLow, Options: CheckOptions{Informational: true}},
+	})
+
+	snapshot := ha.Snapshot()
+	if len(snapshot) != 2 || snapshot["legacy"] != nil {
+		t.Fatalf("Expected legacy to be removed and cache added, got %v", snapshot)
+	}
+	if status := snapshot["db"]; !status.Readiness || status.Checker != newDB || status.Priority != PriorityHigh {
+		t.Errorf("Expected db to keep its state with the new checker and priority, got %+v", status)
+	}
+	if status := snapshot["cache"]; status.checked || !status.Informational {
+		t.Errorf("Expected cache to be added unchecked with its options, got %+v", status)
+	}
+	if live, errs := ha.GetLiveness(); !live {
+		t.Errorf("Expected removed failing check not to affect liveness, got %v", errs)
+	}
+
+	ha.mu.RLock()
+	_, registered := ha.checkers["legacy"]
+	ha.mu.RUnlock()
+	if registered {
+		t.Error("Expected legacy to be removed from auto-update")
+	}
+}
+
+func TestCheckHealthConcurrentBackoff(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx,
+		WithAutoUpdate(time.Second),
+		WithBackoff(time.Minute, 2.0),
+	)
+	checker := &mockHealthChecker{
+		name:         "test",
+		priority:     PriorityCritical,
+		livenessErr:  errors.New("test error"),
+		readinessErr: errors.New("test error"),
+	}
+
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	// Only the first of many concurrent checks may pass the backoff gate
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ha.checkHealth(checker)
+		}()
+	}
+	wg.Wait()
+
+	if runs := checker.checkCount.Load() / 2; runs != 1 {
+		t.Errorf("Expected exactly 1 check while in backoff, got %d", runs)
+	}
+}
+
+// TestConcurrentAccess hammers the aggregator from many goroutines. Run with -race.
+func TestConcurrentAccess(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx,
+		WithAutoUpdate(time.Millisecond),
+		WithInitialDelay(0),
+		WithBackoff(5*time.Millisecond, 2.0),
+	)
+	ha.Start()
+	defer ha.Stop()
+
+	checkers := make([]*mockHealthChecker, 8)
+	for i := range checkers {
+		checkers[i] = &mockHealthChecker{name: fmt.Sprintf("checker%d", i)}
+		if i%2 == 0 {
+			checkers[i].readinessErr = errors.New("not ready")
+		}
+	}
+
+	deadline := time.Now().Add(200 * time.Millisecond)
+	var wg sync.WaitGroup
+	for i, checker := range checkers {
+		wg.Add(4)
+		priority := Priority(i % 4)
+		go func() {
+			defer wg.Done()
+			for time.Now().Before(deadline) {
+				ha.RegisterHealthCheck(checker, priority)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for time.Now().Before(deadline) {
+				ha.UpdateHealth(checker, checker.livenessErr, checker.readinessErr)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for time.Now().Before(deadline) {
+				ha.GetOverallHealth()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for time.Now().Before(deadline) {
+				ha.UnregisterHealthCheck(checker.name)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// FuzzGetLiveness checks that aggregation reports a failure exactly when one exists,
+// and that the reported failure always belongs to the most important failing priority.
+func FuzzGetLiveness(f *testing.F) {
+	f.Add([]byte{0x00, 0x05, 0x07})
+	f.Add([]byte{0x04, 0x04, 0x03})
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ha := NewHealthAggregator(context.Background())
+		now := time.Now()
+
+		failing := false
+		best := PriorityLow + 1
+		for i, b := range data {
+			priority := Priority(b % 4)
+			live := b&0x04 == 0
+			name := fmt.Sprintf("checker%d", i)
+			ha.checkers[name] = &mockHealthChecker{name: name}
+			ha.statuses[name] = &HealthStatus{
+				Priority:   priority,
+				Liveness:   live,
+				Readiness:  true,
+				LastUpdate: now,
+			}
+			if !live {
+				failing = true
+				if priority < best {
+					best = priority
+				}
+			}
+		}
+
+		healthy, errs := ha.GetLiveness()
+		if healthy == failing {
+			t.Fatalf("Expected healthy=%v, got %v", !failing, healthy)
+		}
+		if !failing {
+			return
+		}
+		if len(errs) != 1 {
+			t.Fatalf("Expected exactly 1 error, got %d", len(errs))
+		}
+		for name := range errs {
+			if got := ha.statuses[name].Priority; got != best {
+				t.Errorf("Expected failure at priority %v, got %v", best, got)
+			}
+		}
+	})
+}
+
+// freshnessChecker is a mockHealthChecker that also reports the age of its data
+type freshnessChecker struct {
+	mockHealthChecker
+	age atomic.Int64
+}
+
+func (f *freshnessChecker) DataAge() time.Duration {
+	return time.Duration(f.age.Load())
+}
+
+func TestMaxDataAge(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx, WithMaxDataAge(time.Minute))
+	checker := &freshnessChecker{mockHealthChecker: mockHealthChecker{name: "cache"}}
+
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	checker.age.Store(int64(time.Second))
+	ha.UpdateHealth(checker, nil, nil)
+	time.Sleep(100 * time.Millisecond)
+
+	ready, errs := ha.GetReadiness()
+	if !ready || len(errs) > 0 {
+		t.Error("Expected fresh checker to be ready")
+	}
+
+	// The check still succeeds, but its data has gone stale
+	checker.age.Store(int64(2 * time.Minute))
+
+	ready, errs = ha.GetReadiness()
+	if ready || !errors.Is(errs["cache"], ErrStaleData) {
+		t.Errorf("Expected stale data to fail readiness, got %v", errs)
+	}
+
+	live, _ := ha.GetLiveness()
+	if !live {
+		t.Error("Expected stale data not to affect liveness")
+	}
+}
+
+func TestConfig(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx,
+		WithExpiryTime(10*time.Second),
+		WithAutoUpdate(time.Second),
+		WithStatusChangeCallback(func(name string, status *HealthStatus) {}),
+		WithAuditLog(io.Discard),
+	)
+
+	config := ha.Config()
+	if config.ExpiryTime != 10*time.Second {
+		t.Errorf("Expected expiry time %v, got %v", 10*time.Second, config.ExpiryTime)
+	}
+	if !config.AutoUpdateEnabled || config.CheckInterval != time.Second {
+		t.Error("Expected auto update configuration to be exported")
+	}
+	if config.UpdateBuffer != defaultConfig().UpdateBuffer {
+		t.Errorf("Expected default update buffer, got %d", config.UpdateBuffer)
+	}
+	if config.OnStatusChange != nil {
+		t.Error("Expected status change callback to be omitted")
+	}
+	if config.AuditLog != nil || config.Clock != nil || config.Logger != nil {
+		t.Error("Expected live references to be omitted")
+	}
+	if _, err := json.Marshal(config); err != nil {
+		t.Errorf("Expected the config to serialize, got %v", err)
+	}
+
+	// Mutating the copy must not affect the aggregator
+	config.ExpiryTime = time.Hour
+	if ha.Config().ExpiryTime != 10*time.Second {
+		t.Error("Expected returned config to be a copy")
+	}
+}
+
+func TestExpiryByPriority(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx,
+		WithExpiryTime(time.Hour),
+		WithExpiryByPriority(map[Priority]time.Duration{
+			PriorityLow: 100 * time.Millisecond,
+		}),
+	)
+	critical := &mockHealthChecker{name: "critical", priority: PriorityCritical}
+	low := &mockHealthChecker{name: "low", priority: PriorityLow}
+
+	ha.RegisterHealthCheck(critical, PriorityCritical)
+	ha.RegisterHealthCheck(low, PriorityLow)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(critical, nil, nil)
+	ha.UpdateHealth(low, nil, nil)
+	time.Sleep(150 * time.Millisecond)
+
+	// Only the low priority check uses the short expiry
+	healthy, errs := ha.GetLiveness()
+	if healthy || !errors.Is(errs["low"], ErrHealthCheckExpired) {
+		t.Errorf("Expected low priority check to be expired, got %v", errs)
+	}
+	if _, ok := errs["critical"]; ok {
+		t.Error("Expected critical check to use the global expiry time")
+	}
+}
+
+// hourlyJob expires when it missed its hourly run, with ten minutes of slack
+type hourlyJob struct {
+	mockHealthChecker
+}
+
+func (j *hourlyJob) IsExpired(lastUpdate, now time.Time) bool {
+	return now.Sub(lastUpdate) > 70*time.Minute
+}
+
+func TestExpiryPolicy(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{wall: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	ha := NewHealthAggregator(ctx, WithClock(clock), WithExpiryTime(time.Minute))
+	job := &hourlyJob{mockHealthChecker{name: "job"}}
+	ha.RegisterHealthCheck(job, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(job, nil, nil)
+	time.Sleep(50 * time.Millisecond)
+
+	clock.Advance(time.Hour, time.Hour)
+	if live, errs := ha.GetLiveness(); !live {
+		t.Errorf("Expected the policy to replace the global expiry, got %v", errs)
+	}
+	clock.Advance(15*time.Minute, 15*time.Minute)
+	if live, errs := ha.GetLiveness(); live || !errors.Is(errs["job"], ErrHealthCheckExpired) {
+		t.Errorf("Expected the job to expire per its policy, got %v", errs)
+	}
+}
+
+func TestNoExpiry(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{wall: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	ha := NewHealthAggregator(ctx, WithClock(clock), WithExpiryTime(time.Minute))
+	events := &mockHealthChecker{name: "events"}
+	db := &mockHealthChecker{name: "db"}
+	ha.RegisterHealthCheckWithOptions(events, PriorityCritical, CheckOptions{NoExpiry: true})
+	ha.RegisterHealthCheck(db, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(events, nil, nil)
+	ha.UpdateHealth(db, nil, nil)
+	time.Sleep(50 * time.Millisecond)
+
+	clock.Advance(24*time.Hour, 24*time.Hour)
+	_, errs := ha.GetLiveness()
+	if _, ok := errs["events"]; ok {
+		t.Errorf("Expected the no-expiry check to keep its result, got %v", errs["events"])
+	}
+	if !errors.Is(errs["db"], ErrHealthCheckExpired) {
+		t.Errorf("Expected other checks to still expire, got %v", errs["db"])
+	}
+}
+
+func TestExpiryMissedChecks(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx,
+		WithExpiryTime(time.Hour),
+		WithAutoUpdate(10*time.Second),
+		WithExpiryMissedChecks(3),
+		WithExpiryByPriority(map[Priority]time.Duration{PriorityLow: time.Minute}),
+	)
+
+	if expiry := ha.expiryTime(PriorityCritical); expiry != 30*time.Second {
+		t.Errorf("Expected expiry of 3 check intervals, got %v", expiry)
+	}
+	if expiry := ha.expiryTime(PriorityLow); expiry != time.Minute {
+		t.Errorf("Expected the priority expiry to take precedence, got %v", expiry)
+	}
+
+	// The expiry follows the check interval
+	ha.config.CheckInterval = time.Second
+	if expiry := ha.expiryTime(PriorityCritical); expiry != 3*time.Second {
+		t.Errorf("Expected expiry to follow the check interval, got %v", expiry)
+	}
+}
+
+func TestReadinessTransitionCallbacks(t *testing.T) {
+	ctx := context.Background()
+	var readyCount, notReadyCount atomic.Int32
+	ha := NewHealthAggregator(ctx,
+		WithOnReady(func() { readyCount.Add(1) }),
+		WithOnNotReady(func() { notReadyCount.Add(1) }),
+	)
+	checker := &mockHealthChecker{name: "test", priority: PriorityCritical}
+
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	// Repeated healthy updates fire OnReady only once
+	ha.UpdateHealth(checker, nil, nil)
+	ha.UpdateHealth(checker, nil, nil)
+	time.Sleep(100 * time.Millisecond)
+
+	if readyCount.Load() != 1 || notReadyCount.Load() != 0 {
+		t.Errorf("Expected 1 ready and 0 not ready callbacks, got %d and %d", readyCount.Load(), notReadyCount.Load())
+	}
+
+	ha.UpdateHealth(checker, nil, errors.New("not ready"))
+	time.Sleep(100 * time.Millisecond)
+
+	if readyCount.Load() != 1 || notReadyCount.Load() != 1 {
+		t.Errorf("Expected 1 ready and 1 not ready callbacks, got %d and %d", readyCount.Load(), notReadyCount.Load())
+	}
+}
+
+func TestReadinessDebounce(t *testing.T) {
+	ctx := context.Background()
+	var readyCount, notReadyCount atomic.Int32
+	ha := NewHealthAggregator(ctx,
+		WithOnReady(func() { readyCount.Add(1) }),
+		WithOnNotReady(func() { notReadyCount.Add(1) }),
+		WithReadinessDebounce(200*time.Millisecond),
+	)
+	checker := &mockHealthChecker{name: "test", priority: PriorityCritical}
+
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(checker, nil, nil)
+	time.Sleep(100 * time.Millisecond)
+
+	if readyCount.Load() != 0 {
+		t.Error("Expected OnReady not to fire before the debounce period")
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	if readyCount.Load() != 1 {
+		t.Errorf("Expected OnReady to fire once readiness was stable, got %d", readyCount.Load())
+	}
+
+	// A brief blip shorter than the debounce period is not reported
+	ha.UpdateHealth(checker, nil, errors.New("blip"))
+	time.Sleep(50 * time.Millisecond)
+	ha.UpdateHealth(checker, nil, nil)
+	time.Sleep(300 * time.Millisecond)
+
+	if notReadyCount.Load() != 0 || readyCount.Load() != 1 {
+		t.Errorf("Expected blip to be debounced, got %d ready and %d not ready callbacks", readyCount.Load(), notReadyCount.Load())
+	}
+}
+
+func TestFailingChecks(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	healthy := &mockHealthChecker{name: "healthy"}
+	notLive := &mockHealthChecker{name: "not-live"}
+	notReady := &mockHealthChecker{name: "not-ready"}
+
+	ha.RegisterHealthCheck(healthy, PriorityCritical)
+	ha.RegisterHealthCheck(notLive, PriorityHigh)
+	ha.RegisterHealthCheck(notReady, PriorityLow)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(healthy, nil, nil)
+	ha.UpdateHealth(notLive, errors.New("dead"), nil)
+	ha.UpdateHealth(notReady, nil, errors.New("warming up"))
+	time.Sleep(100 * time.Millisecond)
+
+	failing := ha.FailingChecks()
+	if len(failing) != 2 {
+		t.Fatalf("Expected 2 failing checks, got %d", len(failing))
+	}
+	if _, ok := failing["healthy"]; ok {
+		t.Error("Expected healthy check to be excluded")
+	}
+	if status := failing["not-ready"]; status == nil || status.ReadinessErr == nil {
+		t.Error("Expected not-ready check with its readiness error")
+	}
+
+	// The result is a snapshot, not the live status
+	failing["not-live"].Liveness = true
+	if len(ha.FailingChecks()) != 2 {
+		t.Error("Expected snapshot changes not to affect the aggregator")
+	}
+}
+
+// fakeClock is a Clock whose wall and monotonic readings are set independently
+type fakeClock struct {
+	mu   sync.Mutex
+	wall time.Time
+	mono time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.wall
+}
+
+func (c *fakeClock) Monotonic() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.mono
+}
+
+func (c *fakeClock) Advance(wall, mono time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.wall = c.wall.Add(wall)
+	c.mono += mono
+}
+
+func TestLivenessFromReadiness(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{wall: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	ha := NewHealthAggregator(ctx,
+		WithClock(clock),
+		WithExpiryTime(time.Hour),
+		WithLivenessFromReadiness(5*time.Minute),
+	)
+	checker := &mockHealthChecker{name: "db"}
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(checker, nil, errors.New("connection refused"))
+	time.Sleep(50 * time.Millisecond)
+	clock.Advance(4*time.Minute, 4*time.Minute)
+	if live, errs := ha.GetLiveness(); !live {
+		t.Errorf("Expected liveness to hold during a short readiness failure, got %v", errs)
+	}
+
+	// A repeated failure doesn't restart the clock
+	ha.UpdateHealth(checker, nil, errors.New("connection refused"))
+	time.Sleep(50 * time.Millisecond)
+	clock.Advance(time.Minute, time.Minute)
+	live, errs := ha.GetLiveness()
+	if live || !errors.Is(errs["db"], ErrNotReadyTooLong) {
+		t.Errorf("Expected sustained readiness failure to fail liveness, got %v", errs)
+	}
+
+	// Recovering resets it
+	ha.UpdateHealth(checker, nil, nil)
+	time.Sleep(50 * time.Millisecond)
+	ha.UpdateHealth(checker, nil, errors.New("connection refused"))
+	time.Sleep(50 * time.Millisecond)
+	clock.Advance(time.Minute, time.Minute)
+	if live, errs := ha.GetLiveness(); !live {
+		t.Errorf("Expected a new readiness failure to start over, got %v", errs)
+	}
+}
+
+func TestExpiryIgnoresWallClockJumps(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{wall: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	ha := NewHealthAggregator(ctx, WithClock(clock), WithExpiryTime(10*time.Second))
+	checker := &mockHealthChecker{name: "test", priority: PriorityCritical}
+
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(checker, nil, nil)
+	time.Sleep(100 * time.Millisecond)
+
+	// The wall clock jumps an hour backwards while only a second really passes
+	clock.Advance(-time.Hour, time.Second)
+	if healthy, errs := ha.GetLiveness(); !healthy {
+		t.Errorf("Expected backward clock jump not to affect expiry, got %v", errs)
+	}
+
+	// The wall clock jumps a day forwards while only a second really passes
+	clock.Advance(24*time.Hour, time.Second)
+	if healthy, errs := ha.GetLiveness(); !healthy {
+		t.Errorf("Expected forward clock jump not to expire the check, got %v", errs)
+	}
+
+	// Real elapsed time beyond the expiry still expires the check
+	clock.Advance(0, 10*time.Second)
+	healthy, errs := ha.GetLiveness()
+	if healthy || !errors.Is(errs["test"], ErrHealthCheckExpired) {
+		t.Errorf("Expected check to expire after the expiry time, got %v", errs)
+	}
+}
+
+func TestInformationalCheck(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	critical := &mockHealthChecker{name: "critical"}
+	info := &mockHealthChecker{name: "info"}
+
+	ha.RegisterHealthCheck(critical, PriorityCritical)
+	ha.RegisterHealthCheckWithOptions(info, PriorityCritical, CheckOptions{Informational: true})
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(critical, nil, nil)
+	ha.UpdateHealth(info, errors.New("dead"), errors.New("not ready"))
+	time.Sleep(100 * time.Millisecond)
+
+	liveness, readiness, livenessErrs, readinessErrs := ha.GetOverallHealth()
+	if !liveness || !readiness {
+		t.Errorf("Expected informational failure not to affect health, got %v and %v", livenessErrs, readinessErrs)
+	}
+
+	// The failure is still tracked and reported
+	failing := ha.FailingChecks()
+	if status, ok := failing["info"]; !ok || !status.Informational {
+		t.Error("Expected informational check to be reported as failing")
+	}
+}
+
+func TestResultInterceptor(t *testing.T) {
+	ctx := context.Background()
+	errKnown := errors.New("known issue")
+	errInjected := errors.New("injected")
+	ha := NewHealthAggregator(ctx,
+		WithResultInterceptor(func(name string, livenessErr, readinessErr error) (error, error) {
+			if errors.Is(readinessErr, errKnown) {
+				readinessErr = nil
+			}
+			if name == "auto" {
+				livenessErr = errInjected
+			}
+			return livenessErr, readinessErr
+		}),
+	)
+	manual := &mockHealthChecker{name: "manual"}
+	auto := &mockHealthChecker{name: "auto"}
+
+	ha.RegisterHealthCheck(manual, PriorityCritical)
+	ha.RegisterHealthCheck(auto, PriorityHigh)
+	ha.Start()
+	defer ha.Stop()
+
+	// Manual updates are intercepted
+	ha.UpdateHealth(manual, nil, errKnown)
+	// Auto-update checks are intercepted
+	ha.checkHealth(auto)
+	time.Sleep(100 * time.Millisecond)
+
+	ready, errs := ha.GetReadiness()
+	if !ready {
+		t.Errorf("Expected demoted error not to fail readiness, got %v", errs)
+	}
+
+	_, errs = ha.GetLiveness()
+	if !errors.Is(errs["auto"], errInjected) {
+		t.Errorf("Expected injected liveness failure, got %v", errs)
+	}
+}
+
+func TestResultInterceptorPanic(t *testing.T) {
+	var logs syncBuffer
+	ha := NewHealthAggregator(context.Background(),
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+		WithResultInterceptor(func(name string, livenessErr, readinessErr error) (error, error) {
+			panic("interceptor bug")
+		}),
+	)
+	checker := &mockHealthChecker{name: "db", readinessErr: errors.New("timeout")}
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	// The panic doesn't crash the checking goroutine, and the original results are stored
+	ha.checkHealth(checker)
+	time.Sleep(100 * time.Millisecond)
+
+	if _, errs := ha.GetReadiness(); !errors.Is(errs["db"], checker.readinessErr) {
+		t.Errorf("Expected the uninterpreted readiness error, got %v", errs)
+	}
+	if !strings.Contains(logs.String(), "interceptor bug") {
+		t.Errorf("Expected the panic to be logged, got %q", logs.String())
+	}
+}
+
+// slowHealthChecker takes a fixed time for each of its checks
+type slowHealthChecker struct {
+	mockHealthChecker
+	delay time.Duration
+}
+
+func (s *slowHealthChecker) CheckLiveness() error {
+	time.Sleep(s.delay)
+	return s.mockHealthChecker.CheckLiveness()
+}
+
+func (s *slowHealthChecker) CheckReadiness() error {
+	time.Sleep(s.delay)
+	return s.mockHealthChecker.CheckReadiness()
+}
+
+// hangingChecker blocks its readiness check until release is closed
+type hangingChecker struct {
+	mockHealthChecker
+	release chan struct{}
+}
+
+func (h *hangingChecker) CheckReadiness() error {
+	<-h.release
+	return nil
+}
+
+func TestCheckTimeout(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx, WithCheckTimeout(50*time.Millisecond))
+	checker := &hangingChecker{mockHealthChecker: mockHealthChecker{name: "db"}, release: make(chan struct{})}
+	defer close(checker.release)
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	start := time.Now()
+	status := ha.checkHealth(checker)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Expected the hanging check to be abandoned, took %v", elapsed)
+	}
+	if status.LivenessErr != nil || !errors.Is(status.ReadinessErr, ErrHealthCheckTimeout) {
+		t.Errorf("Expected only readiness to time out, got %v / %v", status.LivenessErr, status.ReadinessErr)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if ready, errs := ha.GetReadiness(); ready || !errors.Is(errs["db"], ErrHealthCheckTimeout) {
+		t.Errorf("Expected the timeout to fail readiness, got %v", errs)
+	}
+	if live, errs := ha.GetLiveness(); !live {
+		t.Errorf("Expected liveness to pass, got %v", errs)
+	}
+}
+
+// contextChecker waits on its context in readiness checks
+type contextChecker struct {
+	mockHealthChecker
+}
+
+func (c *contextChecker) CheckLivenessContext(ctx context.Context) error {
+	return nil
+}
+
+func (c *contextChecker) CheckReadinessContext(ctx context.Context) error {
+	c.checkCount.Add(1)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestContextHealthChecker(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx, WithCheckTimeout(time.Hour))
+	checker := &contextChecker{mockHealthChecker: mockHealthChecker{name: "db"}}
+	ha.Start()
+
+	done := make(chan error, 1)
+	go func() {
+		_, readinessErr := ha.runProbes(ha.ctx, Namespaced("orders", checker))
+		done <- readinessErr
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if checker.checkCount.Load() != 1 {
+		t.Fatal("Expected the context variant to be called through the wrapper")
+	}
+
+	// Stopping the aggregator cancels the check in progress
+	ha.Stop()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the check to be canceled on Stop")
+	}
+
+	// The check timeout is the context deadline
+	ha = NewHealthAggregator(ctx, WithCheckTimeout(50*time.Millisecond))
+	_, readinessErr := ha.runProbes(ctx, checker)
+	if !errors.Is(readinessErr, context.DeadlineExceeded) && !errors.Is(readinessErr, ErrHealthCheckTimeout) {
+		t.Errorf("Expected the check to time out, got %v", readinessErr)
+	}
+}
+
+func TestContextHealthCheckerOnDemand(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	checker := &contextChecker{mockHealthChecker: mockHealthChecker{name: "db"}}
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	ha.UpdateHealth(checker, nil, nil)
+	time.Sleep(50 * time.Millisecond)
+
+	// The caller's deadline reaches the check, and its interrupted result isn't stored
+	deadline, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if ready, errs := ha.GetReadinessContext(deadline); ready || errs["db"] == nil {
+		t.Errorf("Expected the check to be cut short by the caller's deadline, got %v %v", ready, errs)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if ready, errs := ha.GetReadiness(); !ready {
+		t.Errorf("Expected the stored result to be kept, got %v", errs)
+	}
+
+	// After Stop, on-demand checks still get the caller's context rather than a canceled one
+	ha.Stop()
+	deadline, cancel = context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	errs := ha.Validate(deadline)
+	if !errors.Is(errs["db"], context.DeadlineExceeded) && !errors.Is(errs["db"], ErrCheckCanceled) {
+		t.Errorf("Expected validation to run until the caller's deadline, got %v", errs)
+	}
+	if errors.Is(errs["db"], context.Canceled) {
+		t.Errorf("Expected the stopped aggregator not to cancel validation, got %v", errs)
+	}
+}
+
+func TestConcurrentProbes(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx, WithConcurrentProbes(true))
+	checker := &slowHealthChecker{
+		mockHealthChecker: mockHealthChecker{name: "slow", readinessErr: errors.New("not ready")},
+		delay:             100 * time.Millisecond,
+	}
+
+	start := time.Now()
+	livenessErr, readinessErr := ha.runProbes(ctx, checker)
+	elapsed := time.Since(start)
+
+	if elapsed >= 190*time.Millisecond {
+		t.Errorf("Expected probes to run in parallel, took %v", elapsed)
+	}
+	if livenessErr != nil || readinessErr == nil {
+		t.Errorf("Expected results to be preserved, got %v and %v", livenessErr, readinessErr)
+	}
+	if checker.checkCount.Load() != 2 {
+		t.Errorf("Expected both probes to run, got %d", checker.checkCount.Load())
+	}
+}
+
+func TestCheckDueConcurrent(t *testing.T) {
+	ctx := context.Background()
+	newSlow := func(name string) *slowHealthChecker {
+		return &slowHealthChecker{mockHealthChecker: mockHealthChecker{name: name}, delay: 50 * time.Millisecond}
+	}
+
+	// Each checker takes 100ms: 50ms for liveness and 50ms for readiness
+	ha := NewHealthAggregator(ctx)
+	for _, name := range []string{"a", "b", "c"} {
+		ha.RegisterHealthCheck(newSlow(name), PriorityCritical)
+	}
+	ha.Start()
+	defer ha.Stop()
+
+	start := time.Now()
+	ha.checkDue(FixedInterval(time.Minute), make(map[string]time.Time))
+	if elapsed := time.Since(start); elapsed >= 250*time.Millisecond {
+		t.Errorf("Expected checkers to run concurrently, took %v", elapsed)
+	}
+	if runs := ha.Stats()["c"].Runs; runs != 1 {
+		t.Errorf("Expected one run per checker, got %d", runs)
+	}
+
+	bounded := NewHealthAggregator(ctx, WithMaxConcurrency(1))
+	for _, name := range []string{"a", "b", "c"} {
+		bounded.RegisterHealthCheck(newSlow(name), PriorityCritical)
+	}
+	bounded.Start()
+	defer bounded.Stop()
+
+	start = time.Now()
+	bounded.checkDue(FixedInterval(time.Minute), make(map[string]time.Time))
+	if elapsed := time.Since(start); elapsed < 300*time.Millisecond {
+		t.Errorf("Expected MaxConcurrency 1 to run checkers one at a time, took %v", elapsed)
+	}
+}
+
+func TestCheckDueHangingChecker(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx, WithAutoUpdate(100*time.Millisecond))
+	hanging := &hangingChecker{mockHealthChecker: mockHealthChecker{name: "hanging"}, release: make(chan struct{})}
+	fast := &mockHealthChecker{name: "fast"}
+	ha.RegisterHealthCheck(hanging, PriorityCritical)
+	ha.RegisterHealthCheck(fast, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+	defer close(hanging.release)
+
+	due := make(map[string]time.Time)
+	start := time.Now()
+	ha.checkDue(FixedInterval(time.Minute), due)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Expected the round to stop waiting after the check interval, took %v", elapsed)
+	}
+	if fast.checkCount.Load() == 0 {
+		t.Error("Expected the fast checker to run despite the hanging one")
+	}
+	if _, ok := due["hanging"]; !ok {
+		t.Error("Expected the hanging checker to be scheduled")
+	}
+	if running := ha.RunningChecks(); len(running) != 1 || running[0] != "hanging" {
+		t.Errorf("Expected the hanging check to keep running, got %v", running)
+	}
+}
+
+func TestStopWithHangingChecker(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx, WithAutoUpdate(20*time.Millisecond), WithInitialDelay(0))
+	hanging := &hangingChecker{mockHealthChecker: mockHealthChecker{name: "hanging"}, release: make(chan struct{})}
+	defer close(hanging.release)
+	ha.RegisterHealthCheck(hanging, PriorityCritical)
+	ha.Start()
+
+	for deadline := time.Now().Add(time.Second); len(ha.RunningChecks()) == 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the hanging check to start")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// No CheckTimeout bounds the check, so Stop must not wait for it
+	stopped := make(chan struct{})
+	go func() {
+		ha.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Expected Stop to return while a check hangs")
+	}
+}
+
+func TestRegistrationGrace(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{wall: time.Now()}
+	ha := NewHealthAggregator(ctx,
+		WithClock(clock),
+		WithExpiryTime(time.Second),
+		WithRegistrationGrace(10*time.Second),
+	)
+	pending := &mockHealthChecker{name: "pending"}
+	checked := &mockHealthChecker{name: "checked"}
+
+	ha.RegisterHealthCheck(pending, PriorityCritical)
+	ha.RegisterHealthCheck(checked, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(checked, nil, nil)
+	time.Sleep(100 * time.Millisecond)
+	clock.Advance(5*time.Second, 5*time.Second)
+
+	expired := func(name string) bool {
+		ha.mu.RLock()
+		defer ha.mu.RUnlock()
+		return ha.expiryError(name, ha.statuses[name], clock.Monotonic()) != nil
+	}
+
+	if !expired("checked") {
+		t.Error("Expected a checked checker to expire normally")
+	}
+	if expired("pending") {
+		t.Error("Expected a never-checked checker not to expire within the grace period")
+	}
+
+	// Once the grace period has elapsed without an update, normal expiry applies
+	clock.Advance(6*time.Second, 6*time.Second)
+	if !expired("pending") {
+		t.Error("Expected a never-checked checker to expire after the grace period")
+	}
+}
+
+func TestExtendExpiry(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{wall: time.Now()}
+	ha := NewHealthAggregator(ctx, WithClock(clock), WithExpiryTime(time.Minute))
+	checker := &mockHealthChecker{name: "migration"}
+
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(checker, nil, nil)
+	time.Sleep(100 * time.Millisecond)
+
+	ha.ExtendExpiry(checker.name, clock.Now().Add(10*time.Minute))
+
+	// Well past the normal expiry, but within the extension
+	clock.Advance(5*time.Minute, 5*time.Minute)
+	if healthy, errs := ha.GetLiveness(); !healthy {
+		t.Errorf("Expected extended expiry to keep the check alive, got %v", errs)
+	}
+
+	// After the deadline, normal expiry resumes
+	clock.Advance(6*time.Minute, 6*time.Minute)
+	if _, errs := ha.GetLiveness(); !errors.Is(errs[checker.name], ErrHealthCheckExpired) {
+		t.Errorf("Expected check to expire after the extension, got %v", errs)
+	}
+}
+
+func TestCheckHealthSkipsOverlappingRuns(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	checker := &slowHealthChecker{
+		mockHealthChecker: mockHealthChecker{name: "slow"},
+		delay:             100 * time.Millisecond,
+	}
+
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	go ha.checkHealth(checker)
+	time.Sleep(20 * time.Millisecond)
+
+	// Runs scheduled while the previous one is in progress are skipped and recorded
+	ha.checkHealth(checker)
+	ha.checkHealth(checker)
+
+	ha.mu.RLock()
+	skipped := ha.statuses[checker.name].SkippedRuns
+	ha.mu.RUnlock()
+	if skipped != 2 {
+		t.Errorf("Expected 2 skipped runs, got %d", skipped)
+	}
+
+	time.Sleep(300 * time.Millisecond)
+	if runs := checker.checkCount.Load() / 2; runs != 1 {
+		t.Errorf("Expected only 1 check to run, got %d", runs)
+	}
+
+	// A completed check resets the skipped count
+	ha.mu.RLock()
+	skipped = ha.statuses[checker.name].SkippedRuns
+	ha.mu.RUnlock()
+	if skipped != 0 {
+		t.Errorf("Expected skipped runs to reset after a result, got %d", skipped)
+	}
+}
+
+func TestResetStatus(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx, WithAutoUpdate(time.Hour))
+	checker := &mockHealthChecker{
+		name:         "test",
+		livenessErr:  errors.New("down"),
+		readinessErr: errors.New("down"),
+	}
+
+	ha.RegisterHealthCheckWithOptions(checker, PriorityHigh, CheckOptions{Informational: true})
+	ha.Start()
+	defer ha.Stop()
+
+	ha.checkHealth(checker)
+	time.Sleep(100 * time.Millisecond)
+
+	ha.ResetStatus(checker.name)
+
+	ha.mu.RLock()
+	status := ha.statuses[checker.name]
+	backoff := ha.backoffTimes[checker.name]
+	ha.mu.RUnlock()
+
+	if checkStatus(status) != StatusUnknown {
+		t.Errorf("Expected unknown state after reset, got %s", checkStatus(status))
+	}
+	if status.LivenessErr != nil || status.ReadinessErr != nil {
+		t.Error("Expected errors to be cleared")
+	}
+	if status.Priority != PriorityHigh || !status.Informational {
+		t.Error("Expected registration settings to be kept")
+	}
+	if backoff != 0 {
+		t.Errorf("Expected backoff to be cleared, got %v", backoff)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	ha.Start()
+	defer ha.Stop()
+
+	if len(ha.Snapshot()) != 0 {
+		t.Fatal("Expected empty snapshot before registration")
+	}
+
+	checker := &mockHealthChecker{name: "test"}
+	ha.RegisterHealthCheck(checker, PriorityHigh)
+	before := ha.Snapshot()
+
+	ha.UpdateHealth(checker, nil, errors.New("not ready"))
+	time.Sleep(100 * time.Millisecond)
+
+	after := ha.Snapshot()
+	if after["test"] == nil || after["test"].ReadinessErr == nil {
+		t.Error("Expected snapshot to reflect the update")
+	}
+	if before["test"].ReadinessErr != nil {
+		t.Error("Expected earlier snapshot to be unchanged")
+	}
+
+	ha.UnregisterHealthCheck("test")
+	if _, ok := ha.Snapshot()["test"]; ok {
+		t.Error("Expected snapshot to drop unregistered checker")
+	}
+}
+
+// BenchmarkSnapshotUnderUpdates measures readers iterating the snapshot, as a metrics
+// collector would, while updates are being processed; readers never take the aggregator lock
+func BenchmarkSnapshotUnderUpdates(b *testing.B) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ha := NewHealthAggregator(ctx, WithUpdateBuffer(1000))
+	checkers := make([]*mockHealthChecker, 500)
+	for i := range checkers {
+		checkers[i] = &mockHealthChecker{name: fmt.Sprintf("check-%d", i)}
+		ha.RegisterHealthCheck(checkers[i], PriorityMedium)
+	}
+	ha.Start()
+	defer ha.Stop()
+
+	go func() {
+		for i := 0; ctx.Err() == nil; i++ {
+			ha.UpdateHealth(checkers[i%len(checkers)], nil, nil)
+		}
+	}()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			var live int
+			for _, status := range ha.Snapshot() {
+				if status.LivenessErr == nil {
+					live++
+				}
+			}
+		}
+	})
+}
+
+func TestPauseAutoUpdate(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx,
+		WithAutoUpdate(50*time.Millisecond),
+		WithInitialDelay(0),
+	)
+	checker := &mockHealthChecker{name: "test"}
+	ha.RegisterHealthCheck(checker, PriorityHigh)
+	ha.Start()
+	defer ha.Stop()
+
+	time.Sleep(120 * time.Millisecond)
+	ha.PauseAutoUpdate()
+	time.Sleep(60 * time.Millisecond)
+
+	paused := checker.checkCount.Load()
+	if paused == 0 {
+		t.Fatal("Expected checks before pausing")
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	if count := checker.checkCount.Load(); count != paused {
+		t.Errorf("Expected no checks while paused, got %d more", count-paused)
+	}
+
+	ha.UpdateHealth(checker, nil, errors.New("maintenance"))
+	time.Sleep(50 * time.Millisecond)
+	if ready, _ := ha.GetReadiness(); ready {
+		t.Error("Expected manual update to apply while paused")
+	}
+
+	ha.ResumeAutoUpdate()
+	time.Sleep(200 * time.Millisecond)
+	if checker.checkCount.Load() == paused {
+		t.Error("Expected checks to resume")
+	}
+}
+
+func TestGetReadinessContext(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	ha.Start()
+	defer ha.Stop()
+
+	fast := &mockHealthChecker{name: "fast"}
+	slow := &slowHealthChecker{mockHealthChecker: mockHealthChecker{name: "slow"}, delay: 150 * time.Millisecond}
+	ha.RegisterHealthCheck(fast, PriorityCritical)
+	ha.RegisterHealthCheck(slow, PriorityHigh)
+
+	probeCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	ready, errs := ha.GetReadinessContext(probeCtx)
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("Expected answer within the context deadline, took %v", elapsed)
+	}
+	if ready {
+		t.Error("Expected not ready while a check is canceled")
+	}
+	if !errors.Is(errs["slow"], ErrCheckCanceled) {
+		t.Errorf("Expected slow check to be canceled, got %v", errs["slow"])
+	}
+	if _, ok := errs["fast"]; ok {
+		t.Error("Expected fast check to pass")
+	}
+
+	// The canceled check still refreshes the stored status once it completes
+	time.Sleep(400 * time.Millisecond)
+	if ready, _ := ha.GetReadiness(); !ready {
+		t.Error("Expected stored status to be refreshed by completed checks")
+	}
+
+	if ready, errs := ha.GetReadinessContext(ctx); !ready {
+		t.Errorf("Expected ready without a deadline, got %v", errs)
+	}
+}
+
+func TestRunAllWithBudget(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	ha.Start()
+	defer ha.Stop()
+
+	fast := &mockHealthChecker{name: "fast", readinessErr: errors.New("refused")}
+	slow := &slowHealthChecker{mockHealthChecker: mockHealthChecker{name: "slow"}, delay: 150 * time.Millisecond}
+	ha.RegisterHealthCheck(fast, PriorityCritical)
+	ha.RegisterHealthCheck(slow, PriorityHigh)
+
+	start := time.Now()
+	statuses := ha.RunAllWithBudget(ctx, 50*time.Millisecond)
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("Expected answer within the budget, took %v", elapsed)
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("Expected a status for every check, got %v", statuses)
+	}
+	if status := statuses["fast"]; !status.Liveness || status.Readiness || status.ReadinessErr != fast.readinessErr {
+		t.Errorf("Expected the completed result of fast, got %+v", status)
+	}
+	status := statuses["slow"]
+	if status.Readiness || !errors.Is(status.ReadinessErr, ErrCheckCanceled) ||
+		!errors.Is(status.ReadinessErr, context.DeadlineExceeded) {
+		t.Errorf("Expected slow to time out, got %+v", status)
+	}
+}
+
+func TestOnceReady(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	checker := &mockHealthChecker{name: "test"}
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+
+	var calls atomic.Int32
+	ha.OnceReady(func() { calls.Add(1) })
+
+	ha.Start()
+	defer ha.Stop()
+
+	for _, readinessErr := range []error{nil, errors.New("down"), nil} {
+		ha.UpdateHealth(checker, nil, readinessErr)
+		time.Sleep(50 * time.Millisecond)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("Expected callback once, got %d", got)
+	}
+
+	// Registered after the service was ready: called right away
+	var late atomic.Bool
+	ha.OnceReady(func() { late.Store(true) })
+	if !late.Load() {
+		t.Error("Expected late callback to be called immediately")
+	}
+}
+
+func TestStuckChecks(t *testing.T) {
+	ctx := context.Background()
+	var logs syncBuffer
+	ha := NewHealthAggregator(ctx,
+		WithStuckCheckThreshold(50*time.Millisecond),
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+	)
+	checker := &slowHealthChecker{mockHealthChecker: mockHealthChecker{name: "hung"}, delay: 200 * time.Millisecond}
+	ha.RegisterHealthCheck(checker, PriorityHigh)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ha.checkHealth(checker)
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	if running := ha.RunningChecks(); len(running) != 1 || running[0] != "hung" {
+		t.Errorf("Expected hung check to be running, got %v", running)
+	}
+
+	ha.warnStuckChecks()
+	ha.warnStuckChecks()
+	if n := strings.Count(logs.String(), "appears stuck"); n != 1 {
+		t.Errorf("Expected one stuck warning, got %d: %s", n, logs.String())
+	}
+
+	<-done
+	if running := ha.RunningChecks(); len(running) != 0 {
+		t.Errorf("Expected no running checks, got %v", running)
+	}
+}
+
+func TestStuckCheckThresholdDefault(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		opts []Option
+		want time.Duration
+	}{
+		{[]Option{WithAutoUpdate(time.Second)}, 3 * time.Second},
+		{[]Option{WithAutoUpdate(time.Second), WithCheckTimeout(200 * time.Millisecond)}, 600 * time.Millisecond},
+		{[]Option{WithCheckTimeout(200 * time.Millisecond), WithStuckCheckThreshold(time.Minute)}, time.Minute},
+	} {
+		if got := NewHealthAggregator(ctx, tc.opts...).stuckCheckThreshold(); got != tc.want {
+			t.Errorf("Expected threshold %v, got %v", tc.want, got)
+		}
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	ctx := context.Background()
+	var logs syncBuffer
+	ha := NewHealthAggregator(ctx,
+		WithHeartbeat(30*time.Millisecond),
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+	)
+	ok := &mockHealthChecker{name: "ok"}
+	failing := &mockHealthChecker{name: "failing"}
+	ha.RegisterHealthCheck(ok, PriorityHigh)
+	ha.RegisterHealthCheck(failing, PriorityLow)
+	ha.Start()
+
+	ha.UpdateHealth(ok, nil, nil)
+	ha.UpdateHealth(failing, nil, errors.New("down"))
+	time.Sleep(100 * time.Millisecond)
+	ha.Stop()
+
+	out := logs.String()
+	if !strings.Contains(out, "level=INFO msg=\"health heartbeat\" live=true ready=false checks=2 failing=1") {
+		t.Errorf("Expected heartbeat summary, got %q", out)
+	}
+}
+
+func TestRegisterConditional(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx,
+		WithAutoUpdate(30*time.Millisecond),
+		WithInitialDelay(0),
+		WithExpiryTime(50*time.Millisecond),
+	)
+	db := &mockHealthChecker{name: "db", readinessErr: errors.New("connection refused")}
+	lag := &mockHealthChecker{name: "replication-lag", readinessErr: errors.New("lag too high")}
+	ha.RegisterHealthCheck(db, PriorityCritical)
+	ha.RegisterConditional(lag, PriorityHigh, func(ha *HealthAggregator) bool {
+		status := ha.Snapshot()["db"]
+		return status != nil && status.Readiness
+	})
+	ha.Start()
+	defer ha.Stop()
+
+	time.Sleep(150 * time.Millisecond)
+
+	if lag.checkCount.Load() != 0 {
+		t.Error("Expected conditional check not to run while the database is down")
+	}
+	if _, failing := ha.FailingChecks()["replication-lag"]; failing {
+		t.Error("Expected inactive check not to be reported as failing")
+	}
+	for _, check := range ha.DetailedReadinessResponse().Checks {
+		if check.Name == "replication-lag" && check.Status != StatusInactive {
+			t.Errorf("Expected inactive status, got %s", check.Status)
+		}
+	}
+
+	// Replace the database checker with a healthy one
+	ha.RegisterHealthCheck(&mockHealthChecker{name: "db"}, PriorityCritical)
+	time.Sleep(150 * time.Millisecond)
+
+	if lag.checkCount.Load() == 0 {
+		t.Error("Expected conditional check to run once the database is up")
+	}
+	if _, errs := ha.GetReadiness(); errs["replication-lag"] == nil {
+		t.Errorf("Expected active conditional check to fail readiness, got %v", errs)
+	}
+}
+
+func TestConditionalDropsPendingUpdate(t *testing.T) {
+	ha := NewHealthAggregator(context.Background())
+	lag := &mockHealthChecker{name: "replication-lag", readinessErr: errors.New("lag too high")}
+	ha.RegisterConditional(lag, PriorityHigh, func(ha *HealthAggregator) bool { return false })
+
+	// Not started, so the update stays pending while the check is marked inactive
+	ha.UpdateHealth(lag, nil, lag.readinessErr)
+	ha.checkIfActive(lag)
+	ha.Start()
+	defer ha.Stop()
+	time.Sleep(100 * time.Millisecond)
+
+	if status := ha.Snapshot()["replication-lag"]; !status.Inactive {
+		t.Errorf("Expected the stale update not to reactivate the check, got %+v", status)
+	}
+	if _, errs := ha.GetReadiness(); errs["replication-lag"] != nil {
+		t.Errorf("Expected the inactive check not to fail readiness, got %v", errs)
+	}
+}
+
+func TestTryUpdateHealth(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx, WithUpdateBuffer(1))
+	checker := &mockHealthChecker{name: "test"}
+	other := &mockHealthChecker{name: "other"}
+
+	if ok, err := ha.TryUpdateHealth(checker, nil, nil); ok || err != nil {
+		t.Errorf("Expected unregistered checker to be rejected, got %v %v", ok, err)
+	}
+
+	ha.RegisterHealthCheck(checker, PriorityHigh)
+	ha.RegisterHealthCheck(other, PriorityHigh)
+
+	// Not started, so the single pending slot fills up; further updates of the same
+	// checker are coalesced, another checker's are rejected
+	if ok, err := ha.TryUpdateHealth(checker, nil, nil); !ok || err != nil {
+		t.Errorf("Expected update to be queued, got %v %v", ok, err)
+	}
+	if ok, err := ha.TryUpdateHealth(checker, nil, nil); !ok || err != nil {
+		t.Errorf("Expected update to be coalesced, got %v %v", ok, err)
+	}
+	if ok, err := ha.TryUpdateHealth(other, nil, nil); !ok || !errors.Is(err, ErrUpdateQueueFull) {
+		t.Errorf("Expected full queue error, got %v %v", ok, err)
+	}
+
+	ha.Start()
+	ha.Stop()
+	if _, err := ha.TryUpdateHealth(checker, nil, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected canceled error after stop, got %v", err)
+	}
+}
+
+func TestUpdatesCoalesce(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	var applied []string
+	ha := NewHealthAggregator(ctx, WithStatusChangeCallback(func(name string, status *HealthStatus) {
+		mu.Lock()
+		defer mu.Unlock()
+		applied = append(applied, fmt.Sprintf("%s:%v", name, status.ReadinessErr))
+	}))
+	first := &mockHealthChecker{name: "first"}
+	second := &mockHealthChecker{name: "second"}
+	ha.RegisterHealthCheck(first, PriorityHigh)
+	ha.RegisterHealthCheck(second, PriorityHigh)
+
+	// Queued before processing starts: only the latest update per checker survives,
+	// in order of each checker's first pending update
+	for i := 0; i < 100; i++ {
+		ha.UpdateHealth(first, nil, fmt.Errorf("update %d", i))
+	}
+	ha.UpdateHealth(second, nil, nil)
+	ha.UpdateHealth(first, nil, nil)
+
+	ha.Start()
+	defer ha.Stop()
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []string{"first:<nil>", "second:<nil>"}; fmt.Sprint(applied) != fmt.Sprint(want) {
+		t.Errorf("Expected %v, got %v", want, applied)
 	}
 }
@@ -0,0 +1,100 @@
+package gopulse
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// HealthJSONContentType is the media type of the IETF health check response format
+const HealthJSONContentType = "application/health+json"
+
+// Statuses of the health check response format
+const (
+	healthJSONPass = "pass"
+	healthJSONWarn = "warn"
+	healthJSONFail = "fail"
+)
+
+// HealthJSONResponse is a response in the IETF "Health Check Response Format for HTTP APIs"
+// draft format, written by the handlers with WithHealthJSONFormat
+type HealthJSONResponse struct {
+	// Status is "pass", "warn" (degraded) or "fail"
+	Status string `json:"status"`
+	// Output is the reason when not passing
+	Output string `json:"output,omitempty"`
+	// Checks holds the result of each check under its name
+	Checks map[string][]HealthJSONCheck `json:"checks,omitempty"`
+}
+
+// HealthJSONCheck is the result of one check in a HealthJSONResponse
+type HealthJSONCheck struct {
+	// ComponentID is the check's name
+	ComponentID string `json:"componentId"`
+	Status      string `json:"status"`
+	// ObservedValue and ObservedUnit report the check's duration in milliseconds
+	ObservedValue float64 `json:"observedValue,omitempty"`
+	ObservedUnit  string  `json:"observedUnit,omitempty"`
+	// Time is when the check last reported, in RFC 3339 format
+	Time string `json:"time,omitempty"`
+	// Output is the check's error when failing and WithErrorDetails is enabled
+	Output string `json:"output,omitempty"`
+	// AffectedEndpoints are the dependency's endpoints, when its checker is an EndpointReporter
+	AffectedEndpoints []string `json:"affectedEndpoints,omitempty"`
+}
+
+// writeHealthJSON writes the probe result of every non-informational check in the health
+// check response format, with the overall status of the probe's summary response
+func (ha *HealthAggregator) writeHealthJSON(w http.ResponseWriter, summary *PulseResponse, probe probeFunc, liveness bool) {
+	response := &HealthJSONResponse{
+		Status: healthJSONStatus(summary.Status),
+		Output: summary.Reason,
+	}
+	_, details := ha.probeDetails(probe, liveness)
+	if len(details) > 0 {
+		response.Checks = make(map[string][]HealthJSONCheck, len(details))
+	}
+	for name, status := range details {
+		ok, err := status.Readiness, status.ReadinessErr
+		if liveness {
+			ok, err = status.Liveness, status.LivenessErr
+		}
+		check := HealthJSONCheck{ComponentID: name, Status: healthJSONPass}
+		switch {
+		case !ok:
+			check.Status = healthJSONFail
+		case isDegraded(err):
+			check.Status = healthJSONWarn
+		}
+		if check.Status != healthJSONPass && ha.config.ErrorDetails {
+			check.Output = sanitizeError(err)
+		}
+		if status.Duration > 0 {
+			check.ObservedValue = float64(status.Duration) / float64(time.Millisecond)
+			check.ObservedUnit = "ms"
+		}
+		if reporter, ok := checkerAs[EndpointReporter](status.Checker); ok {
+			check.AffectedEndpoints = reporter.Endpoints()
+		}
+		if status.checked {
+			check.Time = status.LastUpdate.Format(time.RFC3339)
+		}
+		response.Checks[name] = []HealthJSONCheck{check}
+	}
+
+	w.Header().Set("Content-Type", HealthJSONContentType)
+	w.WriteHeader(ha.statusCode(summary.Status))
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// healthJSONStatus maps a Status to the health check response format
+func healthJSONStatus(status Status) string {
+	switch status {
+	case StatusUp:
+		return healthJSONPass
+	case StatusDegraded:
+		return healthJSONWarn
+	default:
+		return healthJSONFail
+	}
+}
@@ -0,0 +1,110 @@
+package gopulse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHealthJSONFormat(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx, WithHealthJSONFormat(), WithErrorDetails(true))
+	db := &mockHealthChecker{name: "db"}
+	search := &mockHealthChecker{name: "search"}
+	ha.RegisterHealthCheck(db, PriorityCritical)
+	ha.RegisterHealthCheck(search, PriorityLow)
+	ha.Start()
+	defer ha.Stop()
+
+	get := func(handler http.Handler) (*httptest.ResponseRecorder, *HealthJSONResponse) {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		var response HealthJSONResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+			t.Fatal(err)
+		}
+		return recorder, &response
+	}
+
+	ha.UpdateHealth(db, nil, nil)
+	ha.UpdateHealth(search, nil, Degraded(errors.New("replica lagging")))
+	time.Sleep(100 * time.Millisecond)
+
+	recorder, response := get(ha.ReadinessHandler())
+	if ct := recorder.Header().Get("Content-Type"); ct != HealthJSONContentType {
+		t.Errorf("Expected %s, got %q", HealthJSONContentType, ct)
+	}
+	if recorder.Code != http.StatusOK || response.Status != "warn" {
+		t.Errorf("Expected a degraded service to warn with 200, got %d %+v", recorder.Code, response)
+	}
+	if check := response.Checks["search"]; len(check) != 1 || check[0].Status != "warn" || check[0].Output == "" {
+		t.Errorf("Expected search to warn with its error, got %+v", check)
+	}
+	if check := response.Checks["db"]; len(check) != 1 || check[0].Status != "pass" || check[0].Time == "" {
+		t.Errorf("Expected db to pass with its time, got %+v", check)
+	}
+
+	ha.UpdateHealth(db, nil, errors.New("connection refused"))
+	time.Sleep(100 * time.Millisecond)
+	recorder, response = get(ha.ReadinessHandler())
+	if recorder.Code != http.StatusServiceUnavailable || response.Status != "fail" || response.Output == "" {
+		t.Errorf("Expected fail with 503 and a reason, got %d %+v", recorder.Code, response)
+	}
+	if check := response.Checks["db"]; check[0].Status != "fail" || check[0].Output != "connection refused" {
+		t.Errorf("Expected db to fail, got %+v", check)
+	}
+
+	// Liveness reports the liveness of each check
+	recorder, response = get(ha.LivenessHandler())
+	if recorder.Code != http.StatusOK || response.Status != "pass" || response.Checks["db"][0].Status != "pass" {
+		t.Errorf("Expected liveness to pass, got %d %+v", recorder.Code, response)
+	}
+}
+
+// endpointChecker reports the endpoints of its dependency
+type endpointChecker struct {
+	mockHealthChecker
+	endpoints []string
+}
+
+func (e *endpointChecker) Endpoints() []string { return e.endpoints }
+
+func TestHealthJSONCheckShape(t *testing.T) {
+	ha := NewHealthAggregator(context.Background(), WithHealthJSONFormat())
+	api := &endpointChecker{mockHealthChecker: mockHealthChecker{name: "api"}, endpoints: []string{"http://api/health"}}
+	cache := &mockHealthChecker{name: "cache"}
+	ha.RegisterHealthCheck(api, PriorityCritical)
+	ha.RegisterHealthCheck(cache, PriorityLow)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(api, nil, nil)
+	ha.UpdateHealth(cache, nil, nil)
+	time.Sleep(100 * time.Millisecond)
+
+	recorder := httptest.NewRecorder()
+	ha.ReadinessHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	var response struct {
+		Checks map[string][]map[string]any `json:"checks"`
+	}
+	if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+
+	check := response.Checks["api"][0]
+	if check["componentId"] != "api" || check["status"] != "pass" {
+		t.Errorf("Expected the check's name and status, got %v", check)
+	}
+	if endpoints, _ := check["affectedEndpoints"].([]any); len(endpoints) != 1 || endpoints[0] != "http://api/health" {
+		t.Errorf("Expected the checker's endpoints, got %v", check["affectedEndpoints"])
+	}
+	check = response.Checks["cache"][0]
+	if _, ok := check["affectedEndpoints"]; ok || check["componentId"] != "cache" {
+		t.Errorf("Expected no endpoints when unknown, got %v", check)
+	}
+}
@@ -0,0 +1,163 @@
+package healths
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nduyhai/gopulse"
+)
+
+// ErrClusterRed is wrapped by the readiness error of an Elasticsearch checker whose cluster is red
+var ErrClusterRed = errors.New("cluster red")
+
+// elasticsearchTimeout bounds each cluster health request
+const elasticsearchTimeout = 5 * time.Second
+
+// HTTPDoer sends HTTP requests; *http.Client implements it
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Elasticsearch checks an Elasticsearch or OpenSearch cluster through its _cluster/health
+// endpoint: green is healthy, yellow degrades readiness and red fails it. The cluster status
+// and active shard percentage of the last check are reported as details.
+type Elasticsearch struct {
+	name   string
+	url    string
+	client HTTPDoer
+
+	mu      sync.Mutex
+	details map[string]string
+}
+
+// ElasticsearchOption configures an Elasticsearch checker
+type ElasticsearchOption func(*Elasticsearch)
+
+// WithElasticsearchClient sets the client sending requests, e.g. one adding authentication;
+// it defaults to the client shared by HTTP checkers
+func WithElasticsearchClient(client HTTPDoer) ElasticsearchOption {
+	return func(e *Elasticsearch) {
+		e.client = client
+	}
+}
+
+// NewElasticsearchChecker creates an Elasticsearch checker for the cluster at baseURL,
+// e.g. "http://localhost:9200"
+func NewElasticsearchChecker(name, baseURL string, opts ...ElasticsearchOption) *Elasticsearch {
+	e := &Elasticsearch{
+		name:   name,
+		url:    strings.TrimSuffix(baseURL, "/") + "/_cluster/health",
+		client: defaultHTTPClient,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Name returns the checker name
+func (e *Elasticsearch) Name() string {
+	return e.name
+}
+
+// CheckLiveness always succeeds; an unhealthy cluster should not restart this service
+func (e *Elasticsearch) CheckLiveness() error {
+	return nil
+}
+
+// Endpoints returns the cluster health URL
+func (e *Elasticsearch) Endpoints() []string {
+	return []string{e.url}
+}
+
+// clusterHealth is the part of a _cluster/health response the checker reads
+type clusterHealth struct {
+	Status              string  `json:"status"`
+	ActiveShardsPercent float64 `json:"active_shards_percent_as_number"`
+}
+
+// CheckReadiness requests the cluster health, degrading readiness while yellow and failing
+// it while red or unreachable
+func (e *Elasticsearch) CheckReadiness() error {
+	return e.CheckReadinessContext(context.Background())
+}
+
+// CheckLivenessContext always succeeds, like CheckLiveness
+func (e *Elasticsearch) CheckLivenessContext(ctx context.Context) error {
+	return nil
+}
+
+// CheckReadinessContext is CheckReadiness with the request bound to ctx, so it is canceled
+// with it
+func (e *Elasticsearch) CheckReadinessContext(ctx context.Context) error {
+	health, err := e.clusterHealth(ctx)
+	if err != nil {
+		e.setDetails(nil)
+		return err
+	}
+	e.setDetails(map[string]string{
+		"status":                health.Status,
+		"active_shards_percent": strconv.FormatFloat(health.ActiveShardsPercent, 'f', -1, 64),
+	})
+
+	summary := fmt.Sprintf("%.1f%% of shards active", health.ActiveShardsPercent)
+	switch health.Status {
+	case "green":
+		return nil
+	case "yellow":
+		return gopulse.Degraded(fmt.Errorf("cluster yellow, %s", summary))
+	case "red":
+		return fmt.Errorf("%w, %s", ErrClusterRed, summary)
+	default:
+		return fmt.Errorf("unknown cluster status %q, %s", health.Status, summary)
+	}
+}
+
+// clusterHealth requests and decodes the cluster health
+func (e *Elasticsearch) clusterHealth(ctx context.Context) (*clusterHealth, error) {
+	ctx, cancel := context.WithTimeout(ctx, elasticsearchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// Drain the body so the connection can be reused
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("GET %s: unexpected status code %d", e.url, resp.StatusCode)
+	}
+	var health clusterHealth
+	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+		return nil, fmt.Errorf("GET %s: invalid cluster health: %w", e.url, err)
+	}
+	return &health, nil
+}
+
+// Details returns the cluster status and active shard percentage of the last check
+func (e *Elasticsearch) Details() map[string]string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.details
+}
+
+// setDetails stores the details of the last check
+func (e *Elasticsearch) setDetails(details map[string]string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.details = details
+}
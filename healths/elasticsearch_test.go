@@ -0,0 +1,70 @@
+package healths
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nduyhai/gopulse"
+)
+
+// fakeDoer replies to every request with a canned status and body
+type fakeDoer struct {
+	status int
+	body   string
+	err    error
+	url    string
+}
+
+func (d *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	d.url = req.URL.String()
+	if d.err != nil {
+		return nil, d.err
+	}
+	return &http.Response{StatusCode: d.status, Body: io.NopCloser(strings.NewReader(d.body))}, nil
+}
+
+func TestElasticsearchChecker(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusOK}
+	checker := NewElasticsearchChecker("search", "http://es:9200/", WithElasticsearchClient(doer))
+
+	doer.body = `{"cluster_name":"logs","status":"green","active_shards_percent_as_number":100.0}`
+	if err := checker.CheckReadiness(); err != nil {
+		t.Errorf("Expected green to be ready, got %v", err)
+	}
+	if doer.url != "http://es:9200/_cluster/health" {
+		t.Errorf("Expected the cluster health endpoint, got %s", doer.url)
+	}
+
+	doer.body = `{"status":"yellow","active_shards_percent_as_number":87.5}`
+	err := checker.CheckReadiness()
+	if !errors.Is(err, gopulse.ErrDegraded) || !strings.Contains(err.Error(), "87.5%") {
+		t.Errorf("Expected yellow to degrade with the shard percentage, got %v", err)
+	}
+	if details := checker.Details(); details["status"] != "yellow" || details["active_shards_percent"] != "87.5" {
+		t.Errorf("Unexpected details %v", details)
+	}
+
+	doer.body = `{"status":"red","active_shards_percent_as_number":40}`
+	if err := checker.CheckReadiness(); !errors.Is(err, ErrClusterRed) || errors.Is(err, gopulse.ErrDegraded) {
+		t.Errorf("Expected red to fail readiness, got %v", err)
+	}
+
+	doer.status = http.StatusUnauthorized
+	if err := checker.CheckReadiness(); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("Expected an unexpected status error, got %v", err)
+	}
+	if checker.Details() != nil {
+		t.Errorf("Expected no details without a cluster health, got %v", checker.Details())
+	}
+
+	doer.err = errors.New("connection refused")
+	if err := checker.CheckReadiness(); !errors.Is(err, doer.err) {
+		t.Errorf("Expected the request error, got %v", err)
+	}
+	if checker.CheckLiveness() != nil {
+		t.Error("Expected liveness to be unaffected")
+	}
+}
@@ -0,0 +1,93 @@
+package healths
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"time"
+)
+
+// Failover models a dependency with redundant endpoints (primary, secondary...) as a single
+// check: it tries the endpoints in order and is ready as soon as one answers, reporting the
+// error of every endpoint only when all of them fail
+type Failover struct {
+	name      string
+	endpoints []string
+	check     func(ctx context.Context, endpoint string) error
+}
+
+// FailoverTCPChecker creates a Failover checker that dials each "host:port" address with timeout
+func FailoverTCPChecker(name string, addrs []string, timeout time.Duration) *Failover {
+	return &Failover{
+		name:      name,
+		endpoints: addrs,
+		check: func(ctx context.Context, addr string) error {
+			dialer := net.Dialer{Timeout: timeout}
+			conn, err := dialer.DialContext(ctx, "tcp", addr)
+			if err != nil {
+				return err
+			}
+			return conn.Close()
+		},
+	}
+}
+
+// FailoverHTTPChecker creates a Failover checker requesting each URL like an HTTP checker
+// configured with opts
+func FailoverHTTPChecker(name string, urls []string, opts ...HTTPOption) *Failover {
+	checkers := make(map[string]*HTTP, len(urls))
+	for _, url := range urls {
+		checkers[url] = NewHTTP(name, url, opts...)
+	}
+	return &Failover{
+		name:      name,
+		endpoints: urls,
+		check: func(ctx context.Context, url string) error {
+			return checkers[url].CheckReadinessContext(ctx)
+		},
+	}
+}
+
+// Name returns the checker name
+func (f *Failover) Name() string {
+	return f.name
+}
+
+// Endpoints returns the endpoints tried, in order
+func (f *Failover) Endpoints() []string {
+	return slices.Clone(f.endpoints)
+}
+
+// CheckLiveness always succeeds; an unreachable dependency should not restart this service
+func (f *Failover) CheckLiveness() error {
+	return nil
+}
+
+// CheckReadiness tries the endpoints in order, succeeding on the first one that answers
+func (f *Failover) CheckReadiness() error {
+	return f.CheckReadinessContext(context.Background())
+}
+
+// CheckLivenessContext always succeeds, like CheckLiveness
+func (f *Failover) CheckLivenessContext(ctx context.Context) error {
+	return nil
+}
+
+// CheckReadinessContext is CheckReadiness with every attempt bound to ctx, so it is canceled
+// with it
+func (f *Failover) CheckReadinessContext(ctx context.Context) error {
+	if len(f.endpoints) == 0 {
+		return errors.New("no endpoints configured")
+	}
+	errs := make([]error, 0, len(f.endpoints))
+	for _, endpoint := range f.endpoints {
+		err := f.check(ctx, endpoint)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", endpoint, err))
+	}
+	return fmt.Errorf("all %d endpoints failed: %w", len(f.endpoints), errors.Join(errs...))
+}
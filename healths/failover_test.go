@@ -0,0 +1,81 @@
+package healths
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nduyhai/gopulse/healthtest"
+)
+
+// closedAddr returns the address of a TCP port nothing listens on
+func closedAddr(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+	return addr
+}
+
+func TestFailoverTCPChecker(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	down := closedAddr(t)
+	checker := FailoverTCPChecker("db", []string{down, listener.Addr().String()}, time.Second)
+	if err := checker.CheckReadiness(); err != nil {
+		t.Errorf("Expected the secondary to make the check ready, got %v", err)
+	}
+
+	listener.Close()
+	err = checker.CheckReadiness()
+	if err == nil || !strings.Contains(err.Error(), "all 2 endpoints failed") || !strings.Contains(err.Error(), down) {
+		t.Errorf("Expected the error of every endpoint, got %v", err)
+	}
+}
+
+func TestFailoverHTTPChecker(t *testing.T) {
+	primary := healthtest.NewHTTPServer(healthtest.Response{Status: http.StatusServiceUnavailable})
+	defer primary.Close()
+	secondary := healthtest.NewHTTPServer()
+	defer secondary.Close()
+
+	checker := FailoverHTTPChecker("api", []string{primary.URL, secondary.URL}, WithHTTPTimeout(time.Second))
+	if err := checker.CheckReadiness(); err != nil {
+		t.Errorf("Expected the secondary to make the check ready, got %v", err)
+	}
+	if secondary.Requests() != 1 {
+		t.Errorf("Expected the secondary to be tried after the primary failed")
+	}
+
+	if err := FailoverHTTPChecker("api", nil).CheckReadiness(); err == nil {
+		t.Error("Expected a checker without endpoints to fail")
+	}
+}
+
+func TestFailoverContextCancellation(t *testing.T) {
+	slow := healthtest.NewHTTPServer(healthtest.Response{Delay: 200 * time.Millisecond})
+	defer slow.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := FailoverHTTPChecker("api", []string{slow.URL}).CheckReadinessContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the caller's deadline to cancel the request, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("Expected the request to stop at the deadline, took %v", elapsed)
+	}
+}
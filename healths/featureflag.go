@@ -0,0 +1,67 @@
+package healths
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nduyhai/gopulse"
+)
+
+// featureFlagTimeout bounds each feature-flag provider probe
+const featureFlagTimeout = 5 * time.Second
+
+// FeatureFlag checks a feature-flag or config service (LaunchDarkly, Unleash...) that the
+// service can do without while its local cache of flags is still valid: the provider being
+// down with a valid cache only degrades readiness, both being unavailable fails it
+type FeatureFlag struct {
+	name       string
+	probe      func(ctx context.Context) error
+	cacheValid func() bool
+}
+
+// FeatureFlagChecker creates a FeatureFlag checker; probe checks the provider's connectivity
+// and cacheValid reports whether the locally cached flags can still be served
+func FeatureFlagChecker(name string, probe func(ctx context.Context) error, cacheValid func() bool) *FeatureFlag {
+	return &FeatureFlag{
+		name:       name,
+		probe:      probe,
+		cacheValid: cacheValid,
+	}
+}
+
+// Name returns the checker name
+func (f *FeatureFlag) Name() string {
+	return f.name
+}
+
+// CheckLiveness always succeeds; an unreachable provider should not restart this service
+func (f *FeatureFlag) CheckLiveness() error {
+	return nil
+}
+
+// CheckReadiness probes the provider, falling back to a degraded error while the cache is valid
+func (f *FeatureFlag) CheckReadiness() error {
+	return f.CheckReadinessContext(context.Background())
+}
+
+// CheckLivenessContext always succeeds, like CheckLiveness
+func (f *FeatureFlag) CheckLivenessContext(ctx context.Context) error {
+	return nil
+}
+
+// CheckReadinessContext is CheckReadiness with the probe's context derived from ctx, so it is
+// canceled with it
+func (f *FeatureFlag) CheckReadinessContext(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, featureFlagTimeout)
+	defer cancel()
+
+	err := f.probe(ctx)
+	if err == nil {
+		return nil
+	}
+	if f.cacheValid() {
+		return gopulse.Degraded(fmt.Errorf("flag provider unavailable, serving cached flags: %w", err))
+	}
+	return fmt.Errorf("flag provider unavailable and no valid cache: %w", err)
+}
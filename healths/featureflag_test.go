@@ -0,0 +1,37 @@
+package healths
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nduyhai/gopulse"
+)
+
+func TestFeatureFlagChecker(t *testing.T) {
+	var providerErr error
+	cacheValid := true
+	checker := FeatureFlagChecker("flags",
+		func(ctx context.Context) error { return providerErr },
+		func() bool { return cacheValid },
+	)
+
+	if err := checker.CheckReadiness(); err != nil {
+		t.Errorf("Expected a reachable provider to be ready, got %v", err)
+	}
+
+	providerErr = errors.New("connection refused")
+	err := checker.CheckReadiness()
+	if !errors.Is(err, gopulse.ErrDegraded) || !errors.Is(err, providerErr) {
+		t.Errorf("Expected degraded while the cache is valid, got %v", err)
+	}
+
+	cacheValid = false
+	err = checker.CheckReadiness()
+	if err == nil || errors.Is(err, gopulse.ErrDegraded) || !errors.Is(err, providerErr) {
+		t.Errorf("Expected a failure without a valid cache, got %v", err)
+	}
+	if checker.CheckLiveness() != nil {
+		t.Error("Expected liveness to be unaffected")
+	}
+}
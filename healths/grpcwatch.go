@@ -0,0 +1,164 @@
+package healths
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nduyhai/gopulse"
+)
+
+var (
+	// ErrNotServing is the readiness error of a GRPCWatch checker whose remote reports a status
+	// other than SERVING
+	ErrNotServing = errors.New("remote not serving")
+	// ErrWatchDisconnected is wrapped by the readiness error of a GRPCWatch checker whose
+	// stream is down, while it reconnects
+	ErrWatchDisconnected = errors.New("health watch disconnected")
+)
+
+const (
+	// grpcWatchMinBackoff is the first delay before reconnecting a dropped Watch stream
+	grpcWatchMinBackoff = time.Second
+	// grpcWatchMaxBackoff caps the reconnect delay
+	grpcWatchMaxBackoff = 30 * time.Second
+	// grpcWatchRefresh is how often the last known status is reported again so it doesn't
+	// expire while the remote status holds
+	grpcWatchRefresh = 10 * time.Second
+)
+
+// WatchFunc opens a grpc.health.v1 Health/Watch stream and calls report with whether the remote
+// is SERVING for every message, blocking until the stream ends or ctx is done. Adapting the
+// generated client keeps gRPC out of this module's dependencies:
+//
+//	func(ctx context.Context, report func(serving bool)) error {
+//		stream, err := client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{Service: "orders"})
+//		if err != nil {
+//			return err
+//		}
+//		for {
+//			resp, err := stream.Recv()
+//			if err != nil {
+//				return err
+//			}
+//			report(resp.Status == grpc_health_v1.HealthCheckResponse_SERVING)
+//		}
+//	}
+type WatchFunc func(ctx context.Context, report func(serving bool)) error
+
+// GRPCWatch follows a gRPC dependency through a long-lived Health/Watch stream, pushing each
+// status change to the aggregator instead of polling Check. Register it with
+// CheckOptions.ManualUpdate and start Run.
+type GRPCWatch struct {
+	name       string
+	watch      WatchFunc
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	refresh    time.Duration
+
+	mu  sync.Mutex
+	err error
+}
+
+// GRPCWatchChecker creates a GRPCWatch checker, not ready until the first status arrives
+func GRPCWatchChecker(name string, watch WatchFunc) *GRPCWatch {
+	return &GRPCWatch{
+		name:       name,
+		watch:      watch,
+		minBackoff: grpcWatchMinBackoff,
+		maxBackoff: grpcWatchMaxBackoff,
+		refresh:    grpcWatchRefresh,
+		err:        fmt.Errorf("%w: not connected yet", ErrWatchDisconnected),
+	}
+}
+
+// Name returns the checker name
+func (g *GRPCWatch) Name() string {
+	return g.name
+}
+
+// CheckLiveness always succeeds; an unreachable dependency should not restart this service
+func (g *GRPCWatch) CheckLiveness() error {
+	return nil
+}
+
+// CheckReadiness returns the last status received on the stream, or ErrWatchDisconnected
+// while the stream is down
+func (g *GRPCWatch) CheckReadiness() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.err
+}
+
+// Run keeps the Watch stream open until ctx is done, reporting every status to ha with
+// UpdateHealth. A dropped stream is reported as not ready and reopened with exponential
+// backoff, reset once a status arrives again.
+func (g *GRPCWatch) Run(ctx context.Context, ha *gopulse.HealthAggregator) {
+	refresh := time.NewTicker(g.refresh)
+	defer refresh.Stop()
+
+	backoff := g.minBackoff
+	for ctx.Err() == nil {
+		streamCtx, cancel := context.WithCancel(ctx)
+		done := make(chan error, 1)
+		received := make(chan struct{}, 1)
+		go func() {
+			done <- g.watch(streamCtx, func(serving bool) {
+				var err error
+				if !serving {
+					err = ErrNotServing
+				}
+				g.report(ha, err)
+				select {
+				case received <- struct{}{}:
+				default:
+				}
+			})
+		}()
+
+		err := g.follow(ctx, ha, done, received, refresh.C, &backoff)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			err = errors.New("stream closed")
+		}
+		g.report(ha, fmt.Errorf("%w: %w", ErrWatchDisconnected, err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, g.maxBackoff)
+	}
+}
+
+// follow waits for the stream to end, re-reporting the last status on every refresh tick and
+// resetting backoff once a status arrives
+func (g *GRPCWatch) follow(ctx context.Context, ha *gopulse.HealthAggregator, done <-chan error,
+	received <-chan struct{}, refresh <-chan time.Time, backoff *time.Duration) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-done:
+			return err
+		case <-received:
+			*backoff = g.minBackoff
+		case <-refresh:
+			ha.UpdateHealth(g, nil, g.CheckReadiness())
+		}
+	}
+}
+
+// report stores a readiness error and pushes it to the aggregator
+func (g *GRPCWatch) report(ha *gopulse.HealthAggregator, err error) {
+	g.mu.Lock()
+	g.err = err
+	g.mu.Unlock()
+	ha.UpdateHealth(g, nil, err)
+}
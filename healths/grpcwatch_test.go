@@ -0,0 +1,66 @@
+package healths
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nduyhai/gopulse"
+)
+
+func TestGRPCWatchChecker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ha := gopulse.NewHealthAggregator(ctx, gopulse.WithAutoUpdate(10*time.Millisecond), gopulse.WithInitialDelay(0))
+
+	// Each stream sends the statuses written to it and drops once they are closed
+	streams := make(chan chan bool, 2)
+	opened := make(chan struct{}, 2)
+	checker := GRPCWatchChecker("orders", func(ctx context.Context, report func(bool)) error {
+		opened <- struct{}{}
+		statuses := <-streams
+		for serving := range statuses {
+			report(serving)
+		}
+		return errors.New("connection reset")
+	})
+	checker.minBackoff = 10 * time.Millisecond
+	ha.RegisterHealthCheckWithOptions(checker, gopulse.PriorityCritical, gopulse.CheckOptions{ManualUpdate: true})
+	ha.Start()
+	defer ha.Stop()
+	go checker.Run(ctx, ha)
+
+	readiness := func() error {
+		time.Sleep(50 * time.Millisecond)
+		return ha.Snapshot()["orders"].ReadinessErr
+	}
+
+	first := make(chan bool)
+	streams <- first
+	<-opened
+	first <- true
+	if err := readiness(); err != nil {
+		t.Errorf("Expected ready while serving, got %v", err)
+	}
+	first <- false
+	if err := readiness(); !errors.Is(err, ErrNotServing) {
+		t.Errorf("Expected ErrNotServing, got %v", err)
+	}
+
+	// A dropped stream reports not ready, then reconnects
+	second := make(chan bool)
+	streams <- second
+	close(first)
+	if err := readiness(); !errors.Is(err, ErrWatchDisconnected) {
+		t.Errorf("Expected ErrWatchDisconnected, got %v", err)
+	}
+	<-opened
+	second <- true
+	if err := readiness(); err != nil {
+		t.Errorf("Expected ready after reconnecting, got %v", err)
+	}
+	if checker.CheckLiveness() != nil {
+		t.Error("Expected liveness to be unaffected")
+	}
+}
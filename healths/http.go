@@ -0,0 +1,177 @@
+package healths
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// defaultHTTPClient is shared by all HTTP checkers that don't supply their own client, so
+// polling a dependency every few seconds reuses keep-alive connections instead of dialing
+// (and leaving behind) a new one per check.
+var defaultHTTPClient = &http.Client{
+	Timeout:   5 * time.Second,
+	Transport: newHTTPTransport(),
+}
+
+// newHTTPTransport returns a keep-alive transport with a bounded idle connection pool
+func newHTTPTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 100
+	transport.MaxIdleConnsPerHost = 2
+	transport.IdleConnTimeout = 90 * time.Second
+	transport.ForceAttemptHTTP2 = true
+	return transport
+}
+
+// HTTP checks that an HTTP endpoint answers with an expected status code, any 2xx by default.
+// Checkers should never create a new http.Client per check; use the shared
+// default client or inject a long-lived one with WithHTTPClient.
+type HTTP struct {
+	name     string
+	url      string
+	client   *http.Client
+	method   string
+	body     []byte
+	expected []int
+	timeout  time.Duration
+}
+
+// HTTPOption configures an HTTP checker
+type HTTPOption func(*HTTP)
+
+// WithHTTPClient sets the client used for requests, e.g. one with a custom
+// transport for HTTP/2, TLS or proxies. The client should be reused across checks.
+func WithHTTPClient(client *http.Client) HTTPOption {
+	return func(h *HTTP) {
+		h.client = client
+	}
+}
+
+// WithHTTPMethod sets the request method, e.g. http.MethodHead or http.MethodPost
+func WithHTTPMethod(method string) HTTPOption {
+	return func(h *HTTP) {
+		h.method = method
+	}
+}
+
+// WithHTTPBody sets the request body sent with every check
+func WithHTTPBody(body []byte) HTTPOption {
+	return func(h *HTTP) {
+		h.body = body
+	}
+}
+
+// WithExpectedStatus sets the status codes considered healthy, replacing the default of any 2xx
+func WithExpectedStatus(codes ...int) HTTPOption {
+	return func(h *HTTP) {
+		h.expected = codes
+	}
+}
+
+// WithHTTPTimeout bounds each check independently of the client and the aggregator: the
+// client's own Timeout is ignored, so it may be longer than the default client's 5s
+func WithHTTPTimeout(timeout time.Duration) HTTPOption {
+	return func(h *HTTP) {
+		h.timeout = timeout
+	}
+}
+
+// NewHTTP creates an HTTP checker for the given URL
+func NewHTTP(name, url string, opts ...HTTPOption) *HTTP {
+	h := &HTTP{
+		name:   name,
+		url:    url,
+		client: defaultHTTPClient,
+		method: http.MethodGet,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	if h.timeout > 0 && h.client.Timeout > 0 {
+		// Rely on the request context alone; the copy shares the client's transport, so
+		// connections are still reused
+		client := *h.client
+		client.Timeout = 0
+		h.client = &client
+	}
+	return h
+}
+
+// Name returns the checker name
+func (h *HTTP) Name() string {
+	return h.name
+}
+
+// CheckLiveness always succeeds; an unreachable dependency should not restart this service
+func (h *HTTP) CheckLiveness() error {
+	return nil
+}
+
+// CheckReadiness requests the endpoint and fails on errors or unexpected status codes
+func (h *HTTP) CheckReadiness() error {
+	return h.CheckReadinessContext(context.Background())
+}
+
+// CheckLivenessContext always succeeds, like CheckLiveness
+func (h *HTTP) CheckLivenessContext(ctx context.Context) error {
+	return nil
+}
+
+// CheckReadinessContext is CheckReadiness with the request bound to ctx, so it is canceled
+// with it
+func (h *HTTP) CheckReadinessContext(ctx context.Context) error {
+	if h.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.timeout)
+		defer cancel()
+	}
+
+	var body io.Reader
+	if h.body != nil {
+		body = bytes.NewReader(h.body)
+	}
+	req, err := http.NewRequestWithContext(ctx, h.method, h.url, body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if !h.expectedStatus(resp.StatusCode) {
+		return fmt.Errorf("%s %s: unexpected status code %d, expected %s", h.method, h.url, resp.StatusCode, h.expectedString())
+	}
+	return nil
+}
+
+// Endpoints returns the checked URL
+func (h *HTTP) Endpoints() []string {
+	return []string{h.url}
+}
+
+// expectedStatus reports whether the status code is considered healthy
+func (h *HTTP) expectedStatus(code int) bool {
+	if len(h.expected) == 0 {
+		return code >= 200 && code <= 299
+	}
+	return slices.Contains(h.expected, code)
+}
+
+// expectedString describes the healthy status codes for error messages
+func (h *HTTP) expectedString() string {
+	if len(h.expected) == 0 {
+		return "2xx"
+	}
+	return fmt.Sprint(h.expected)
+}
@@ -0,0 +1,156 @@
+package healths
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nduyhai/gopulse/healthtest"
+)
+
+// newCountingServer returns a test server that counts the connections opened to it
+func newCountingServer(status int) (*httptest.Server, *atomic.Int32) {
+	var conns atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	server.Start()
+	return server, &conns
+}
+
+func TestHTTPReusesConnections(t *testing.T) {
+	server, conns := newCountingServer(http.StatusOK)
+	defer server.Close()
+
+	checker := NewHTTP("upstream", server.URL)
+	for i := 0; i < 10; i++ {
+		if err := checker.CheckReadiness(); err != nil {
+			t.Fatalf("Expected upstream to be ready, got %v", err)
+		}
+	}
+
+	if n := conns.Load(); n != 1 {
+		t.Errorf("Expected 1 connection to be reused, got %d", n)
+	}
+}
+
+func TestHTTPUnexpectedStatus(t *testing.T) {
+	server := healthtest.NewHTTPServer(
+		healthtest.Response{Status: http.StatusServiceUnavailable},
+		healthtest.Response{Status: http.StatusOK},
+	)
+	defer server.Close()
+
+	client := &http.Client{}
+	checker := NewHTTP("upstream", server.URL, WithHTTPClient(client))
+	if err := checker.CheckReadiness(); err == nil {
+		t.Error("Expected 503 to fail readiness")
+	}
+	if err := checker.CheckLiveness(); err != nil {
+		t.Errorf("Expected liveness to be unaffected, got %v", err)
+	}
+	if err := checker.CheckReadiness(); err != nil {
+		t.Errorf("Expected recovery once upstream answers 200, got %v", err)
+	}
+}
+
+func BenchmarkHTTPCheckReadiness(b *testing.B) {
+	server, conns := newCountingServer(http.StatusOK)
+	defer server.Close()
+
+	checker := NewHTTP("upstream", server.URL)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := checker.CheckReadiness(); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(conns.Load()), "conns")
+}
+
+func TestHTTPMethodBodyAndExpectedStatus(t *testing.T) {
+	var gotMethod, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotMethod, gotBody = r.Method, string(body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	checker := NewHTTP("upstream", server.URL,
+		WithHTTPMethod(http.MethodPost),
+		WithHTTPBody([]byte(`{"probe":true}`)),
+		WithExpectedStatus(http.StatusOK, http.StatusAccepted),
+	)
+	if err := checker.CheckReadiness(); err != nil {
+		t.Fatalf("Expected 202 to be accepted, got %v", err)
+	}
+	if gotMethod != http.MethodPost || gotBody != `{"probe":true}` {
+		t.Errorf("Expected POST with body, got %s %q", gotMethod, gotBody)
+	}
+
+	checker = NewHTTP("upstream", server.URL, WithExpectedStatus(http.StatusOK))
+	err := checker.CheckReadiness()
+	if err == nil || !strings.Contains(err.Error(), "202") || !strings.Contains(err.Error(), "[200]") {
+		t.Errorf("Expected error with actual and expected status, got %v", err)
+	}
+}
+
+func TestHTTPTimeout(t *testing.T) {
+	server := healthtest.NewHTTPServer(healthtest.Response{Delay: 200 * time.Millisecond})
+	defer server.Close()
+
+	checker := NewHTTP("slow", server.URL, WithHTTPTimeout(20*time.Millisecond))
+	if err := checker.CheckReadiness(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected timeout, got %v", err)
+	}
+}
+
+func TestHTTPTimeoutOverridesClientTimeout(t *testing.T) {
+	server := healthtest.NewHTTPServer(healthtest.Response{Delay: 100 * time.Millisecond})
+	defer server.Close()
+
+	client := &http.Client{Timeout: 20 * time.Millisecond}
+	checker := NewHTTP("slow", server.URL, WithHTTPClient(client), WithHTTPTimeout(time.Second))
+	if err := checker.CheckReadiness(); err != nil {
+		t.Errorf("Expected the longer checker timeout to apply, got %v", err)
+	}
+	if client.Timeout != 20*time.Millisecond {
+		t.Error("Expected the supplied client not to be modified")
+	}
+
+	checker = NewHTTP("default", server.URL, WithHTTPTimeout(10*time.Second))
+	if checker.client.Timeout != 0 || checker.client.Transport != defaultHTTPClient.Transport {
+		t.Error("Expected the default client's timeout to be lifted, keeping its transport")
+	}
+}
+
+func TestHTTPContextCancellation(t *testing.T) {
+	server := healthtest.NewHTTPServer(healthtest.Response{Delay: 200 * time.Millisecond})
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := NewHTTP("slow", server.URL).CheckReadinessContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the caller's deadline to cancel the request, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("Expected the request to stop at the deadline, took %v", elapsed)
+	}
+}
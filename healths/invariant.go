@@ -0,0 +1,38 @@
+package healths
+
+import "fmt"
+
+// Invariant continuously validates a configuration or environment invariant, such as a
+// required environment variable being present, and surfaces drift as a liveness failure
+// instead of a silent misconfiguration. The check runs on every liveness check, so it
+// must be cheap.
+type Invariant struct {
+	name  string
+	check func() error
+}
+
+// InvariantChecker creates an Invariant checker that fails liveness when check returns an error
+func InvariantChecker(name string, check func() error) *Invariant {
+	return &Invariant{
+		name:  name,
+		check: check,
+	}
+}
+
+// Name returns the checker name
+func (i *Invariant) Name() string {
+	return i.name
+}
+
+// CheckLiveness validates the invariant
+func (i *Invariant) CheckLiveness() error {
+	if err := i.check(); err != nil {
+		return fmt.Errorf("invariant %s violated: %w", i.name, err)
+	}
+	return nil
+}
+
+// CheckReadiness always succeeds; invariants are liveness-scoped
+func (i *Invariant) CheckReadiness() error {
+	return nil
+}
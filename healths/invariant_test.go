@@ -0,0 +1,31 @@
+package healths
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestInvariantChecker(t *testing.T) {
+	errMissing := errors.New("DATABASE_URL is not set")
+	var violation error
+	i := InvariantChecker("config", func() error { return violation })
+
+	if err := i.CheckLiveness(); err != nil {
+		t.Errorf("Expected a held invariant to pass, got %v", err)
+	}
+
+	violation = errMissing
+	err := i.CheckLiveness()
+	if !errors.Is(err, errMissing) || !strings.Contains(err.Error(), "invariant config violated") {
+		t.Errorf("Expected the violation to fail liveness with the wrapped error, got %v", err)
+	}
+	if i.CheckReadiness() != nil {
+		t.Error("Expected readiness to be unaffected by a violated invariant")
+	}
+
+	violation = nil
+	if err := i.CheckLiveness(); err != nil {
+		t.Errorf("Expected liveness to recover once the invariant holds again, got %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package healths
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotLeader is the readiness error of a Leader checker whose instance doesn't hold the lock
+var ErrNotLeader = errors.New("not leader")
+
+// Leader reports a singleton workload as ready only while this instance holds its distributed
+// lock (etcd, Consul, a database advisory lock...), so traffic is routed to the leader only
+type Leader struct {
+	name     string
+	isLeader func() (bool, error)
+}
+
+// LeaderChecker creates a Leader checker; isLeader reports whether the lock is currently held
+func LeaderChecker(name string, isLeader func() (bool, error)) *Leader {
+	return &Leader{
+		name:     name,
+		isLeader: isLeader,
+	}
+}
+
+// Name returns the checker name
+func (l *Leader) Name() string {
+	return l.name
+}
+
+// CheckLiveness always succeeds; followers are healthy, just not serving
+func (l *Leader) CheckLiveness() error {
+	return nil
+}
+
+// CheckReadiness fails with ErrNotLeader on followers, and with a wrapped backend error when
+// leadership can't be determined
+func (l *Leader) CheckReadiness() error {
+	held, err := l.isLeader()
+	if err != nil {
+		return fmt.Errorf("lock backend: %w", err)
+	}
+	if !held {
+		return ErrNotLeader
+	}
+	return nil
+}
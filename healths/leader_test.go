@@ -0,0 +1,30 @@
+package healths
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLeaderChecker(t *testing.T) {
+	var held bool
+	var backendErr error
+	checker := LeaderChecker("leader", func() (bool, error) { return held, backendErr })
+
+	if err := checker.CheckReadiness(); !errors.Is(err, ErrNotLeader) {
+		t.Errorf("Expected ErrNotLeader on a follower, got %v", err)
+	}
+
+	held = true
+	if err := checker.CheckReadiness(); err != nil {
+		t.Errorf("Expected leader to be ready, got %v", err)
+	}
+
+	backendErr = errors.New("etcd unavailable")
+	err := checker.CheckReadiness()
+	if !errors.Is(err, backendErr) || errors.Is(err, ErrNotLeader) {
+		t.Errorf("Expected backend error distinct from ErrNotLeader, got %v", err)
+	}
+	if checker.CheckLiveness() != nil {
+		t.Error("Expected liveness to be unaffected")
+	}
+}
@@ -0,0 +1,45 @@
+package healths
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrOverloaded is wrapped by the readiness error of a Load checker over its limit
+var ErrOverloaded = errors.New("overloaded")
+
+// Load sheds load by reporting the service as not ready while too many requests are in
+// flight, so the load balancer stops sending new ones until the backlog drains
+type Load struct {
+	name     string
+	inFlight func() int
+	max      int
+}
+
+// LoadChecker creates a Load checker that fails readiness when inFlight reports more than max
+// requests, e.g. from a gauge incremented and decremented by a middleware
+func LoadChecker(name string, inFlight func() int, max int) *Load {
+	return &Load{
+		name:     name,
+		inFlight: inFlight,
+		max:      max,
+	}
+}
+
+// Name returns the checker name
+func (l *Load) Name() string {
+	return l.name
+}
+
+// CheckLiveness always succeeds; being busy should not restart the service
+func (l *Load) CheckLiveness() error {
+	return nil
+}
+
+// CheckReadiness compares the current in-flight request count to the limit
+func (l *Load) CheckReadiness() error {
+	if n := l.inFlight(); n > l.max {
+		return fmt.Errorf("%w: %d requests in flight exceed %d", ErrOverloaded, n, l.max)
+	}
+	return nil
+}
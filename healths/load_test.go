@@ -0,0 +1,35 @@
+package healths
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLoadChecker(t *testing.T) {
+	inFlight := 0
+	l := LoadChecker("http", func() int { return inFlight }, 100)
+
+	if err := l.CheckReadiness(); err != nil {
+		t.Errorf("Expected ready when idle, got %v", err)
+	}
+
+	inFlight = 100
+	if err := l.CheckReadiness(); err != nil {
+		t.Errorf("Expected ready at the limit, got %v", err)
+	}
+
+	inFlight = 140
+	err := l.CheckReadiness()
+	if !errors.Is(err, ErrOverloaded) || !strings.Contains(err.Error(), "140") {
+		t.Errorf("Expected an overload error reporting the in-flight count, got %v", err)
+	}
+	if l.CheckLiveness() != nil {
+		t.Error("Expected liveness to be unaffected by load")
+	}
+
+	inFlight = 20
+	if err := l.CheckReadiness(); err != nil {
+		t.Errorf("Expected ready once drained, got %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package healths
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nduyhai/gopulse"
+)
+
+// Logic combines checkers with boolean logic, to express readiness conditions such as
+// "primary OR (replica AND replica-lag)" without a custom checker. Liveness and readiness
+// are combined separately, each operand being checked with the same probe; NOT only negates
+// readiness.
+type Logic struct {
+	op       string
+	checkers []gopulse.HealthChecker
+}
+
+// And passes when every checker passes; the error lists each failing branch
+func And(checkers ...gopulse.HealthChecker) *Logic {
+	return &Logic{op: "AND", checkers: checkers}
+}
+
+// Or passes when any checker passes, checking them in order until one does; the error
+// lists why every branch failed. Without checkers it always fails.
+func Or(checkers ...gopulse.HealthChecker) *Logic {
+	return &Logic{op: "OR", checkers: checkers}
+}
+
+// Not is ready when checker isn't ready and not ready when it is. Liveness is passed through
+// unchanged: most checkers are always live, and negating that would get the process restarted.
+func Not(checker gopulse.HealthChecker) *Logic {
+	return &Logic{op: "NOT", checkers: []gopulse.HealthChecker{checker}}
+}
+
+// Name returns the expression, e.g. "primary OR (replica AND replica-lag)"
+func (l *Logic) Name() string {
+	names := make([]string, len(l.checkers))
+	for i, checker := range l.checkers {
+		names[i] = checker.Name()
+		if _, nested := checker.(*Logic); nested {
+			names[i] = "(" + names[i] + ")"
+		}
+	}
+	if l.op == "NOT" {
+		return "NOT " + names[0]
+	}
+	return strings.Join(names, " "+l.op+" ")
+}
+
+// CheckLiveness combines the liveness of the checkers
+func (l *Logic) CheckLiveness() error {
+	return l.evalLiveness(gopulse.HealthChecker.CheckLiveness)
+}
+
+// CheckReadiness combines the readiness of the checkers
+func (l *Logic) CheckReadiness() error {
+	return l.eval(gopulse.HealthChecker.CheckReadiness)
+}
+
+// CheckLivenessContext combines the liveness of the checkers, passing ctx to context-aware ones
+func (l *Logic) CheckLivenessContext(ctx context.Context) error {
+	return l.evalLiveness(func(checker gopulse.HealthChecker) error { return checkLivenessContext(ctx, checker) })
+}
+
+// CheckReadinessContext combines the readiness of the checkers, passing ctx to context-aware
+// ones
+func (l *Logic) CheckReadinessContext(ctx context.Context) error {
+	return l.eval(func(checker gopulse.HealthChecker) error { return checkReadinessContext(ctx, checker) })
+}
+
+// Details returns the operand's details for a single-operand expression such as Not, when
+// it is a gopulse.DetailProvider. Freshness and expiry aren't passed on, as they describe the
+// operand's result rather than the expression's.
+func (l *Logic) Details() map[string]string {
+	if len(l.checkers) != 1 {
+		return nil
+	}
+	if provider, ok := l.checkers[0].(gopulse.DetailProvider); ok {
+		return provider.Details()
+	}
+	return nil
+}
+
+// evalLiveness is eval for the liveness probe, which NOT passes through unchanged
+func (l *Logic) evalLiveness(probe func(gopulse.HealthChecker) error) error {
+	if l.op == "NOT" {
+		return probe(l.checkers[0])
+	}
+	return l.eval(probe)
+}
+
+// eval applies the operator to the results of probe on the checkers
+func (l *Logic) eval(probe func(gopulse.HealthChecker) error) error {
+	if l.op == "OR" && len(l.checkers) == 0 {
+		// No branch can pass
+		return errors.New("OR without branches")
+	}
+	var errs []error
+	for _, checker := range l.checkers {
+		err := probe(checker)
+		switch {
+		case l.op == "NOT" && err == nil:
+			return fmt.Errorf("%s passed", checker.Name())
+		case l.op == "NOT":
+			return nil
+		case l.op == "OR" && err == nil:
+			return nil
+		case err != nil:
+			errs = append(errs, fmt.Errorf("%s: %w", checker.Name(), err))
+		}
+	}
+	if l.op == "OR" && len(errs) > 0 {
+		return fmt.Errorf("no branch of %s passed: %w", l.Name(), errors.Join(errs...))
+	}
+	return errors.Join(errs...)
+}
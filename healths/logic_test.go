@@ -0,0 +1,78 @@
+package healths
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// stubChecker reports a fixed readiness error
+type stubChecker struct {
+	name string
+	err  error
+}
+
+func (s *stubChecker) Name() string          { return s.name }
+func (s *stubChecker) CheckLiveness() error  { return nil }
+func (s *stubChecker) CheckReadiness() error { return s.err }
+
+func TestLogic(t *testing.T) {
+	primary := &stubChecker{name: "primary", err: errors.New("primary down")}
+	replica := &stubChecker{name: "replica"}
+	lag := &stubChecker{name: "replica-lag", err: errors.New("lag 2m")}
+	checker := Or(primary, And(replica, lag))
+
+	if name := checker.Name(); name != "primary OR (replica AND replica-lag)" {
+		t.Errorf("Unexpected name %q", name)
+	}
+
+	err := checker.CheckReadiness()
+	if err == nil || !errors.Is(err, primary.err) || !errors.Is(err, lag.err) {
+		t.Fatalf("Expected both failing branches in the error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "replica-lag: lag 2m") {
+		t.Errorf("Expected the error to name the failing branch, got %v", err)
+	}
+	if err := checker.CheckLiveness(); err != nil {
+		t.Errorf("Expected liveness to pass, got %v", err)
+	}
+
+	lag.err = nil
+	if err := checker.CheckReadiness(); err != nil {
+		t.Errorf("Expected the replica branch to pass, got %v", err)
+	}
+
+	if err := Not(replica).CheckReadiness(); err == nil {
+		t.Error("Expected NOT of a passing checker to fail")
+	}
+	if err := Not(primary).CheckReadiness(); err != nil {
+		t.Errorf("Expected NOT of a failing checker to pass, got %v", err)
+	}
+}
+
+func TestNotKeepsLiveness(t *testing.T) {
+	if err := Not(&stubChecker{name: "replica"}).CheckLiveness(); err != nil {
+		t.Errorf("Expected NOT of a live checker to stay live, got %v", err)
+	}
+	dead := &livenessStub{err: errors.New("deadlocked")}
+	if err := Not(dead).CheckLiveness(); !errors.Is(err, dead.err) {
+		t.Errorf("Expected NOT to pass liveness failures through, got %v", err)
+	}
+}
+
+// livenessStub reports a fixed liveness error
+type livenessStub struct{ err error }
+
+func (s *livenessStub) Name() string          { return "dead" }
+func (s *livenessStub) CheckLiveness() error  { return s.err }
+func (s *livenessStub) CheckReadiness() error { return nil }
+
+func TestEmptyOr(t *testing.T) {
+	checker := Or()
+	if checker.CheckReadiness() == nil || checker.CheckLiveness() == nil {
+		t.Error("Expected an OR without branches to fail")
+	}
+	if err := And().CheckReadiness(); err != nil {
+		t.Errorf("Expected an AND without operands to pass, got %v", err)
+	}
+}
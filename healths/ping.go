@@ -0,0 +1,269 @@
+package healths
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// Ping checks network-level reachability and latency of a host. Each readiness check sends one
+// ICMP echo request, falling back to timing a TCP connect when the process isn't allowed to open
+// raw ICMP sockets. Readiness fails when packet loss or the average round-trip time over the
+// recent window exceeds the configured thresholds.
+type Ping struct {
+	name    string
+	host    string
+	port    string
+	timeout time.Duration
+	window  int
+	maxLoss float64
+	maxRTT  time.Duration
+
+	mu      sync.Mutex
+	useTCP  bool
+	seq     uint16
+	samples []pingSample
+}
+
+// pingSample is the outcome of a single probe
+type pingSample struct {
+	rtt  time.Duration
+	lost bool
+}
+
+// PingOption configures a Ping checker
+type PingOption func(*Ping)
+
+// WithPingTimeout sets how long to wait for each probe's reply
+func WithPingTimeout(timeout time.Duration) PingOption {
+	return func(p *Ping) {
+		p.timeout = timeout
+	}
+}
+
+// WithPingWindow sets the number of recent probes considered for loss and RTT; sizes below 1
+// are raised to 1, the latest probe
+func WithPingWindow(size int) PingOption {
+	return func(p *Ping) {
+		p.window = max(size, 1)
+	}
+}
+
+// WithPingThresholds sets the maximum packet loss ratio (0-1) and average RTT tolerated
+func WithPingThresholds(maxLoss float64, maxRTT time.Duration) PingOption {
+	return func(p *Ping) {
+		p.maxLoss = maxLoss
+		p.maxRTT = maxRTT
+	}
+}
+
+// WithPingTCPPort sets the port used for TCP-connect timing when ICMP isn't permitted
+func WithPingTCPPort(port string) PingOption {
+	return func(p *Ping) {
+		p.port = port
+	}
+}
+
+// PingChecker creates a Ping checker for the given host
+func PingChecker(name, host string, opts ...PingOption) *Ping {
+	p := &Ping{
+		name:    name,
+		host:    host,
+		port:    "80",
+		timeout: time.Second,
+		window:  10,
+		maxLoss: 0.5,
+		maxRTT:  500 * time.Millisecond,
+		seq:     uint16(os.Getpid()),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Name returns the checker name
+func (p *Ping) Name() string {
+	return p.name
+}
+
+// CheckLiveness always succeeds; network trouble should not restart this service
+func (p *Ping) CheckLiveness() error {
+	return nil
+}
+
+// CheckReadiness sends one probe and evaluates loss and RTT over the recent window
+func (p *Ping) CheckReadiness() error {
+	return p.CheckReadinessContext(context.Background())
+}
+
+// CheckLivenessContext always succeeds, like CheckLiveness
+func (p *Ping) CheckLivenessContext(ctx context.Context) error {
+	return nil
+}
+
+// CheckReadinessContext is CheckReadiness with the probe bound to ctx, so it is canceled
+// with it
+func (p *Ping) CheckReadinessContext(ctx context.Context) error {
+	rtt, err := p.probe(ctx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.samples = append(p.samples, pingSample{rtt: rtt, lost: err != nil})
+	if len(p.samples) > p.window {
+		p.samples = p.samples[len(p.samples)-p.window:]
+	}
+
+	loss, avg := p.statsLocked()
+	if loss > p.maxLoss {
+		if err != nil {
+			return fmt.Errorf("%s: packet loss %.0f%% over last %d probes: %w", p.host, loss*100, len(p.samples), err)
+		}
+		return fmt.Errorf("%s: packet loss %.0f%% over last %d probes", p.host, loss*100, len(p.samples))
+	}
+	if p.maxRTT > 0 && avg > p.maxRTT {
+		return fmt.Errorf("%s: average rtt %s exceeds %s", p.host, avg, p.maxRTT)
+	}
+	return nil
+}
+
+// AverageRTT returns the average round-trip time of the successful probes in the window
+func (p *Ping) AverageRTT() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, avg := p.statsLocked()
+	return avg
+}
+
+// ReportedDuration reports the average RTT as the check duration
+func (p *Ping) ReportedDuration() time.Duration {
+	return p.AverageRTT()
+}
+
+// statsLocked returns the loss ratio and average RTT of the window; p.mu must be held
+func (p *Ping) statsLocked() (float64, time.Duration) {
+	if len(p.samples) == 0 {
+		return 0, 0
+	}
+
+	var lost int
+	var total time.Duration
+	for _, sample := range p.samples {
+		if sample.lost {
+			lost++
+			continue
+		}
+		total += sample.rtt
+	}
+
+	var avg time.Duration
+	if received := len(p.samples) - lost; received > 0 {
+		avg = total / time.Duration(received)
+	}
+	return float64(lost) / float64(len(p.samples)), avg
+}
+
+// probe measures one round trip, over ICMP when permitted and TCP otherwise
+func (p *Ping) probe(ctx context.Context) (time.Duration, error) {
+	p.mu.Lock()
+	useTCP := p.useTCP
+	p.seq++
+	seq := p.seq
+	p.mu.Unlock()
+
+	if !useTCP {
+		rtt, err := p.probeICMP(ctx, seq)
+		if !errors.Is(err, os.ErrPermission) {
+			return rtt, err
+		}
+		// Unprivileged process: remember to use TCP timing from now on
+		p.mu.Lock()
+		p.useTCP = true
+		p.mu.Unlock()
+	}
+	return p.probeTCP(ctx)
+}
+
+// probeTCP times a TCP connect to the host
+func (p *Ping) probeTCP(ctx context.Context) (time.Duration, error) {
+	dialer := net.Dialer{Timeout: p.timeout}
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(p.host, p.port))
+	if err != nil {
+		return 0, err
+	}
+	rtt := time.Since(start)
+	_ = conn.Close()
+	return rtt, nil
+}
+
+// probeICMP sends an ICMP echo request and waits for the matching reply
+func (p *Ping) probeICMP(ctx context.Context, seq uint16) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip4", p.host)
+	if err != nil {
+		return 0, err
+	}
+	addr := &net.IPAddr{IP: ips[0]}
+
+	var lc net.ListenConfig
+	conn, err := lc.ListenPacket(ctx, "ip4:icmp", "0.0.0.0")
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	// Unblock the read as soon as ctx is done, not only at the deadline
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	id := uint16(os.Getpid())
+	start := time.Now()
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return 0, err
+	}
+	if _, err := conn.WriteTo(icmpEcho(id, seq), addr); err != nil {
+		return 0, err
+	}
+
+	reply := make([]byte, 1500)
+	for {
+		n, from, err := conn.ReadFrom(reply)
+		if err != nil {
+			return 0, err
+		}
+		// Echo reply (type 0) for our identifier and sequence from the target
+		if n >= 8 && reply[0] == 0 && from.String() == addr.String() &&
+			binary.BigEndian.Uint16(reply[4:6]) == id && binary.BigEndian.Uint16(reply[6:8]) == seq {
+			return time.Since(start), nil
+		}
+	}
+}
+
+// icmpEcho builds an ICMP echo request message
+func icmpEcho(id, seq uint16) []byte {
+	msg := make([]byte, 16)
+	msg[0] = 8 // echo request
+	binary.BigEndian.PutUint16(msg[4:6], id)
+	binary.BigEndian.PutUint16(msg[6:8], seq)
+	copy(msg[8:], "gopulse!")
+
+	var sum uint32
+	for i := 0; i < len(msg); i += 2 {
+		sum += uint32(msg[i])<<8 | uint32(msg[i+1])
+	}
+	sum = (sum >> 16) + (sum & 0xffff)
+	sum += sum >> 16
+	binary.BigEndian.PutUint16(msg[2:4], ^uint16(sum))
+	return msg
+}
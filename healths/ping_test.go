@@ -0,0 +1,91 @@
+package healths
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestPingTCPFallback(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	p := PingChecker("loopback", "127.0.0.1", WithPingTCPPort(port))
+	p.useTCP = true
+
+	if err := p.CheckReadiness(); err != nil {
+		t.Fatalf("Expected loopback to be ready, got %v", err)
+	}
+	if p.AverageRTT() <= 0 {
+		t.Error("Expected a positive average RTT")
+	}
+	if p.ReportedDuration() != p.AverageRTT() {
+		t.Error("Expected the reported duration to be the average RTT")
+	}
+}
+
+func TestPingThresholds(t *testing.T) {
+	p := PingChecker("flaky", "127.0.0.1", WithPingWindow(4), WithPingThresholds(0.25, 10*time.Millisecond))
+
+	p.samples = []pingSample{{rtt: time.Millisecond}, {lost: true}, {lost: true}, {rtt: 3 * time.Millisecond}}
+	loss, avg := p.statsLocked()
+	if loss != 0.5 || avg != 2*time.Millisecond {
+		t.Errorf("Expected 50%% loss and 2ms average, got %v and %v", loss, avg)
+	}
+}
+
+func TestPingWindowClamped(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	for _, size := range []int{-3, 0} {
+		p := PingChecker("loopback", "127.0.0.1", WithPingTCPPort(port), WithPingWindow(size))
+		p.useTCP = true
+
+		for range 2 {
+			if err := p.CheckReadiness(); err != nil {
+				t.Fatalf("Expected loopback to be ready with window %d, got %v", size, err)
+			}
+		}
+		if len(p.samples) != 1 {
+			t.Errorf("Expected window %d to keep the latest probe, got %d samples", size, len(p.samples))
+		}
+	}
+}
+
+func TestICMPEchoChecksum(t *testing.T) {
+	msg := icmpEcho(0x1234, 7)
+
+	// A valid ICMP checksum makes the one's complement sum of the message 0xffff
+	var sum uint32
+	for i := 0; i < len(msg); i += 2 {
+		sum += uint32(msg[i])<<8 | uint32(msg[i+1])
+	}
+	for sum > 0xffff {
+		sum = (sum >> 16) + (sum & 0xffff)
+	}
+	if sum != 0xffff {
+		t.Errorf("Expected valid checksum, got sum %#x", sum)
+	}
+}
+
+func TestPingContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := PingChecker("unreachable", "127.0.0.1", WithPingWindow(1))
+	p.useTCP = true
+	if err := p.CheckReadinessContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the canceled context to stop the probe, got %v", err)
+	}
+}
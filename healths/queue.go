@@ -0,0 +1,43 @@
+package healths
+
+import "fmt"
+
+// QueueDepth reports a queue consumer as not ready when its backlog grows beyond a limit,
+// signalling that it can't keep up and producers or traffic should be throttled
+type QueueDepth struct {
+	name     string
+	depthFn  func() (int, error)
+	maxDepth int
+}
+
+// QueueDepthChecker creates a QueueDepth checker that fails readiness when depthFn reports
+// more than maxDepth pending items
+func QueueDepthChecker(name string, depthFn func() (int, error), maxDepth int) *QueueDepth {
+	return &QueueDepth{
+		name:     name,
+		depthFn:  depthFn,
+		maxDepth: maxDepth,
+	}
+}
+
+// Name returns the checker name
+func (q *QueueDepth) Name() string {
+	return q.name
+}
+
+// CheckLiveness always succeeds; a backlog should not restart the consumer
+func (q *QueueDepth) CheckLiveness() error {
+	return nil
+}
+
+// CheckReadiness compares the current queue depth to the limit
+func (q *QueueDepth) CheckReadiness() error {
+	depth, err := q.depthFn()
+	if err != nil {
+		return fmt.Errorf("queue depth unavailable: %w", err)
+	}
+	if depth > q.maxDepth {
+		return fmt.Errorf("queue depth %d exceeds %d", depth, q.maxDepth)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package healths
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestQueueDepthChecker(t *testing.T) {
+	depth := 0
+	var depthErr error
+	q := QueueDepthChecker("jobs", func() (int, error) { return depth, depthErr }, 100)
+
+	if err := q.CheckReadiness(); err != nil {
+		t.Errorf("Expected ready with empty queue, got %v", err)
+	}
+
+	depth = 100
+	if err := q.CheckReadiness(); err != nil {
+		t.Errorf("Expected ready at the limit, got %v", err)
+	}
+
+	depth = 250
+	err := q.CheckReadiness()
+	if err == nil || !strings.Contains(err.Error(), "250") {
+		t.Errorf("Expected error reporting current depth, got %v", err)
+	}
+	if q.CheckLiveness() != nil {
+		t.Error("Expected liveness to be unaffected by backlog")
+	}
+
+	depthErr = errors.New("broker unreachable")
+	if err := q.CheckReadiness(); !errors.Is(err, depthErr) {
+		t.Errorf("Expected depth error to be wrapped, got %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package healths
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// BucketChecker is the part of an object-store client an S3 checker needs, so no SDK version
+// is pinned. Adapt e.g. the AWS SDK with a HeadBucket call returning its error as is.
+type BucketChecker interface {
+	HeadBucket(ctx context.Context, bucket string) error
+}
+
+var (
+	// ErrBucketNotFound is wrapped by S3 readiness errors when the bucket doesn't exist
+	ErrBucketNotFound = errors.New("bucket not found")
+	// ErrBucketAccessDenied is wrapped by S3 readiness errors when the credentials lack access
+	ErrBucketAccessDenied = errors.New("bucket access denied")
+	// ErrBucketUnreachable is wrapped by S3 readiness errors for network and other failures
+	ErrBucketUnreachable = errors.New("bucket unreachable")
+)
+
+// s3Timeout bounds each HeadBucket call
+const s3Timeout = 5 * time.Second
+
+// S3 checks that an object-store bucket is reachable
+type S3 struct {
+	name   string
+	client BucketChecker
+	bucket string
+}
+
+// NewS3Checker creates an S3 checker calling HeadBucket on bucket
+func NewS3Checker(name string, client BucketChecker, bucket string) *S3 {
+	return &S3{
+		name:   name,
+		client: client,
+		bucket: bucket,
+	}
+}
+
+// Name returns the checker name
+func (s *S3) Name() string {
+	return s.name
+}
+
+// CheckLiveness always succeeds; an unreachable bucket should not restart this service
+func (s *S3) CheckLiveness() error {
+	return nil
+}
+
+// CheckReadiness calls HeadBucket, classifying failures as ErrBucketNotFound,
+// ErrBucketAccessDenied or ErrBucketUnreachable
+func (s *S3) CheckReadiness() error {
+	return s.CheckReadinessContext(context.Background())
+}
+
+// CheckLivenessContext always succeeds, like CheckLiveness
+func (s *S3) CheckLivenessContext(ctx context.Context) error {
+	return nil
+}
+
+// CheckReadinessContext is CheckReadiness with the HeadBucket context derived from ctx, so
+// it is canceled with it
+func (s *S3) CheckReadinessContext(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s3Timeout)
+	defer cancel()
+
+	err := s.client.HeadBucket(ctx, s.bucket)
+	if err == nil {
+		return nil
+	}
+	return &bucketError{bucket: s.bucket, class: classifyBucketError(err), err: err}
+}
+
+// apiError is implemented by AWS SDK (smithy) API errors
+type apiError interface {
+	ErrorCode() string
+}
+
+// statusCoder is implemented by AWS SDK HTTP response errors
+type statusCoder interface {
+	HTTPStatusCode() int
+}
+
+// classifyBucketError maps a client error to one of the bucket error sentinels
+func classifyBucketError(err error) error {
+	var api apiError
+	if errors.As(err, &api) {
+		switch api.ErrorCode() {
+		case "NotFound", "NoSuchBucket":
+			return ErrBucketNotFound
+		case "AccessDenied", "Forbidden", "InvalidAccessKeyId", "SignatureDoesNotMatch":
+			return ErrBucketAccessDenied
+		}
+	}
+	var status statusCoder
+	if errors.As(err, &status) {
+		switch status.HTTPStatusCode() {
+		case http.StatusNotFound:
+			return ErrBucketNotFound
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return ErrBucketAccessDenied
+		}
+	}
+	return ErrBucketUnreachable
+}
+
+// bucketError is an S3 readiness error of a class, reporting its kind to detailed responses
+type bucketError struct {
+	bucket string
+	class  error
+	err    error
+}
+
+func (e *bucketError) Error() string {
+	return fmt.Sprintf("bucket %s: %v: %v", e.bucket, e.class, e.err)
+}
+
+func (e *bucketError) Unwrap() []error {
+	return []error{e.class, e.err}
+}
+
+// Kind classifies the error as "not_found", "auth" or "network"
+func (e *bucketError) Kind() string {
+	switch e.class {
+	case ErrBucketNotFound:
+		return "not_found"
+	case ErrBucketAccessDenied:
+		return "auth"
+	default:
+		return "network"
+	}
+}
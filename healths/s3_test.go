@@ -0,0 +1,77 @@
+package healths
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeBucketClient returns a fixed HeadBucket error
+type fakeBucketClient struct {
+	err error
+}
+
+func (c *fakeBucketClient) HeadBucket(ctx context.Context, bucket string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return c.err
+}
+
+// fakeAPIError mimics an AWS SDK API error
+type fakeAPIError struct {
+	code string
+}
+
+func (e *fakeAPIError) Error() string     { return "api error " + e.code }
+func (e *fakeAPIError) ErrorCode() string { return e.code }
+
+// fakeResponseError mimics an AWS SDK HTTP response error without an error code
+type fakeResponseError struct {
+	status int
+}
+
+func (e *fakeResponseError) Error() string       { return "http response error" }
+func (e *fakeResponseError) HTTPStatusCode() int { return e.status }
+
+func TestS3Checker(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+		want error
+		kind string
+	}{
+		{"not found code", &fakeAPIError{code: "NoSuchBucket"}, ErrBucketNotFound, "not_found"},
+		{"access denied code", &fakeAPIError{code: "AccessDenied"}, ErrBucketAccessDenied, "auth"},
+		{"not found status", &fakeResponseError{status: 404}, ErrBucketNotFound, "not_found"},
+		{"forbidden status", &fakeResponseError{status: 403}, ErrBucketAccessDenied, "auth"},
+		{"network", errors.New("dial tcp: connection refused"), ErrBucketUnreachable, "network"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := NewS3Checker("assets", &fakeBucketClient{err: tc.err}, "assets-bucket")
+			err := checker.CheckReadiness()
+			if !errors.Is(err, tc.want) || !errors.Is(err, tc.err) {
+				t.Errorf("Expected %v wrapping the client error, got %v", tc.want, err)
+			}
+			var kinder interface{ Kind() string }
+			if !errors.As(err, &kinder) || kinder.Kind() != tc.kind {
+				t.Errorf("Expected kind %q, got %v", tc.kind, err)
+			}
+		})
+	}
+
+	checker := NewS3Checker("assets", &fakeBucketClient{}, "assets-bucket")
+	if err := checker.CheckReadiness(); err != nil {
+		t.Errorf("Expected a reachable bucket to be ready, got %v", err)
+	}
+}
+
+func TestS3CheckerContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := NewS3Checker("assets", &fakeBucketClient{}, "assets-bucket").CheckReadinessContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected HeadBucket to get the caller's context, got %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package healths
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/nduyhai/gopulse"
+)
+
+// Window is a daily period during which a dependency is expected to be up
+type Window struct {
+	// Days the window starts on, empty means every day
+	Days []time.Weekday
+	// Start and End are wall-clock times of day, as offsets from midnight; an End before
+	// Start spans midnight
+	Start time.Duration
+	End   time.Duration
+}
+
+// Schedule is a set of availability windows in a time zone
+type Schedule struct {
+	// Location the windows are expressed in, nil means UTC
+	Location *time.Location
+	Windows  []Window
+}
+
+// Active reports whether t falls inside one of the schedule's windows
+func (s Schedule) Active(t time.Time) bool {
+	loc := s.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	t = t.In(loc)
+	// Wall-clock time of day rather than time elapsed since midnight, which is off by the
+	// shift on DST transition days
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+	yesterday := (t.Weekday() + 6) % 7
+
+	for _, w := range s.Windows {
+		if w.Start <= w.End {
+			if w.onDay(t.Weekday()) && offset >= w.Start && offset < w.End {
+				return true
+			}
+			continue
+		}
+		// Overnight window: the evening part of today's or the morning part of yesterday's
+		if w.onDay(t.Weekday()) && offset >= w.Start || w.onDay(yesterday) && offset < w.End {
+			return true
+		}
+	}
+	return false
+}
+
+// onDay reports whether the window starts on day
+func (w Window) onDay(day time.Weekday) bool {
+	return len(w.Days) == 0 || slices.Contains(w.Days, day)
+}
+
+// Scheduled only checks a dependency during its availability windows, such as a batch
+// system that is only up during business hours. Outside the windows it reports healthy
+// without calling the wrapped checker, so off-hours downtime doesn't raise false alarms.
+type Scheduled struct {
+	inner    gopulse.HealthChecker
+	schedule Schedule
+	now      func() time.Time
+}
+
+// ScheduledChecker wraps inner so it is only checked while schedule is active
+func ScheduledChecker(inner gopulse.HealthChecker, schedule Schedule) *Scheduled {
+	return &Scheduled{
+		inner:    inner,
+		schedule: schedule,
+		now:      time.Now,
+	}
+}
+
+// Name returns the wrapped checker's name
+func (s *Scheduled) Name() string {
+	return s.inner.Name()
+}
+
+// CheckLiveness calls the wrapped checker's CheckLiveness inside the schedule's windows
+func (s *Scheduled) CheckLiveness() error {
+	if !s.schedule.Active(s.now()) {
+		return nil
+	}
+	return s.inner.CheckLiveness()
+}
+
+// CheckReadiness calls the wrapped checker's CheckReadiness inside the schedule's windows
+func (s *Scheduled) CheckReadiness() error {
+	if !s.schedule.Active(s.now()) {
+		return nil
+	}
+	return s.inner.CheckReadiness()
+}
+
+// CheckLivenessContext is CheckLiveness passing ctx to a context-aware wrapped checker
+func (s *Scheduled) CheckLivenessContext(ctx context.Context) error {
+	if !s.schedule.Active(s.now()) {
+		return nil
+	}
+	return checkLivenessContext(ctx, s.inner)
+}
+
+// CheckReadinessContext is CheckReadiness passing ctx to a context-aware wrapped checker
+func (s *Scheduled) CheckReadinessContext(ctx context.Context) error {
+	if !s.schedule.Active(s.now()) {
+		return nil
+	}
+	return checkReadinessContext(ctx, s.inner)
+}
+
+// Details returns the wrapped checker's details when it is a gopulse.DetailProvider. Other
+// optional interfaces such as gopulse.FreshnessReporter aren't passed on, as the wrapped
+// checker's data going stale outside the windows must not fail the check.
+func (s *Scheduled) Details() map[string]string {
+	if provider, ok := s.inner.(gopulse.DetailProvider); ok {
+		return provider.Details()
+	}
+	return nil
+}
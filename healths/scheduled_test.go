@@ -0,0 +1,105 @@
+package healths
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScheduleActive(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+	schedule := Schedule{
+		Location: berlin,
+		Windows: []Window{
+			{Days: []time.Weekday{time.Monday, time.Tuesday}, Start: 9 * time.Hour, End: 17 * time.Hour},
+			{Days: []time.Weekday{time.Friday}, Start: 22 * time.Hour, End: 2 * time.Hour},
+		},
+	}
+
+	for _, tc := range []struct {
+		at   time.Time
+		want bool
+	}{
+		{time.Date(2024, 6, 3, 9, 0, 0, 0, berlin), true},    // Monday opening
+		{time.Date(2024, 6, 3, 7, 30, 0, 0, time.UTC), true}, // Monday 9:30 in Berlin
+		{time.Date(2024, 6, 3, 17, 0, 0, 0, berlin), false},  // Monday closing
+		{time.Date(2024, 6, 5, 12, 0, 0, 0, berlin), false},  // Wednesday
+		{time.Date(2024, 6, 7, 23, 0, 0, 0, berlin), true},   // Friday night
+		{time.Date(2024, 6, 8, 1, 0, 0, 0, berlin), true},    // Saturday early morning
+		{time.Date(2024, 6, 8, 23, 0, 0, 0, berlin), false},  // Saturday night
+	} {
+		if got := schedule.Active(tc.at); got != tc.want {
+			t.Errorf("Active(%v) = %v, want %v", tc.at, got, tc.want)
+		}
+	}
+}
+
+func TestScheduleActiveAcrossDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+	schedule := Schedule{
+		Location: berlin,
+		Windows:  []Window{{Start: 4 * time.Hour, End: 6 * time.Hour}},
+	}
+
+	// Clocks go forward on 2024-03-31 and back on 2024-10-27: windows follow the wall clock
+	for _, tc := range []struct {
+		at   time.Time
+		want bool
+	}{
+		{time.Date(2024, 3, 31, 3, 30, 0, 0, berlin), false},
+		{time.Date(2024, 3, 31, 4, 30, 0, 0, berlin), true},
+		{time.Date(2024, 3, 31, 6, 30, 0, 0, berlin), false},
+		{time.Date(2024, 10, 27, 3, 30, 0, 0, berlin), false},
+		{time.Date(2024, 10, 27, 5, 30, 0, 0, berlin), true},
+		{time.Date(2024, 10, 27, 6, 30, 0, 0, berlin), false},
+	} {
+		if got := schedule.Active(tc.at); got != tc.want {
+			t.Errorf("Active(%v) = %v, want %v", tc.at, got, tc.want)
+		}
+	}
+}
+
+func TestScheduledChecker(t *testing.T) {
+	inner := &countingChecker{err: errors.New("batch system down")}
+	checker := ScheduledChecker(inner, Schedule{
+		Windows: []Window{{Start: 9 * time.Hour, End: 17 * time.Hour}},
+	})
+
+	checker.now = func() time.Time { return time.Date(2024, 6, 3, 3, 0, 0, 0, time.UTC) }
+	if err := checker.CheckReadiness(); err != nil {
+		t.Errorf("Expected healthy outside the window, got %v", err)
+	}
+	if inner.calls != 0 {
+		t.Error("Expected inner checker not to be called outside the window")
+	}
+
+	checker.now = func() time.Time { return time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC) }
+	if err := checker.CheckReadiness(); err == nil {
+		t.Error("Expected inner failure inside the window")
+	}
+}
+
+// countingChecker counts its calls and fails readiness with err
+type countingChecker struct {
+	err   error
+	calls int
+}
+
+func (c *countingChecker) Name() string {
+	return "counting"
+}
+
+func (c *countingChecker) CheckLiveness() error {
+	return nil
+}
+
+func (c *countingChecker) CheckReadiness() error {
+	c.calls++
+	return c.err
+}
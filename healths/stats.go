@@ -0,0 +1,127 @@
+package healths
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nduyhai/gopulse"
+)
+
+// statsWindow is the number of most recent calls kept for percentile calculation
+const statsWindow = 1024
+
+// Stats summarizes the recent latency and success rate of a checker's calls
+type Stats struct {
+	Calls       int64
+	Failures    int64
+	SuccessRate float64
+	P50         time.Duration
+	P95         time.Duration
+	P99         time.Duration
+}
+
+// Statistics wraps a HealthChecker, timing each liveness and readiness call and keeping
+// latency percentiles over the most recent calls and the overall success rate
+type Statistics struct {
+	inner gopulse.HealthChecker
+
+	mu        sync.Mutex
+	calls     int64
+	failures  int64
+	latencies []time.Duration
+	next      int
+}
+
+// WithStats wraps inner so its calls are measured; read the results with Stats
+func WithStats(inner gopulse.HealthChecker) *Statistics {
+	return &Statistics{
+		inner:     inner,
+		latencies: make([]time.Duration, 0, statsWindow),
+	}
+}
+
+// Name returns the wrapped checker's name
+func (s *Statistics) Name() string {
+	return s.inner.Name()
+}
+
+// CheckLiveness calls the wrapped checker's CheckLiveness and records the outcome
+func (s *Statistics) CheckLiveness() error {
+	return s.record(s.inner.CheckLiveness)
+}
+
+// CheckReadiness calls the wrapped checker's CheckReadiness and records the outcome
+func (s *Statistics) CheckReadiness() error {
+	return s.record(s.inner.CheckReadiness)
+}
+
+// CheckLivenessContext is CheckLiveness passing ctx to a context-aware wrapped checker
+func (s *Statistics) CheckLivenessContext(ctx context.Context) error {
+	return s.record(func() error { return checkLivenessContext(ctx, s.inner) })
+}
+
+// CheckReadinessContext is CheckReadiness passing ctx to a context-aware wrapped checker
+func (s *Statistics) CheckReadinessContext(ctx context.Context) error {
+	return s.record(func() error { return checkReadinessContext(ctx, s.inner) })
+}
+
+// Unwrap returns the wrapped checker, so its optional interfaces keep working
+func (s *Statistics) Unwrap() gopulse.HealthChecker {
+	return s.inner
+}
+
+// Stats returns a snapshot of the recorded statistics
+func (s *Statistics) Stats() Stats {
+	s.mu.Lock()
+	stats := Stats{Calls: s.calls, Failures: s.failures}
+	latencies := append([]time.Duration(nil), s.latencies...)
+	s.mu.Unlock()
+
+	if stats.Calls > 0 {
+		stats.SuccessRate = float64(stats.Calls-stats.Failures) / float64(stats.Calls)
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	stats.P50 = percentile(latencies, 0.50)
+	stats.P95 = percentile(latencies, 0.95)
+	stats.P99 = percentile(latencies, 0.99)
+	return stats
+}
+
+// record times a single call
+func (s *Statistics) record(check func() error) error {
+	start := time.Now()
+	err := check()
+	elapsed := time.Since(start)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+	if err != nil {
+		s.failures++
+	}
+	if len(s.latencies) < statsWindow {
+		s.latencies = append(s.latencies, elapsed)
+	} else {
+		s.latencies[s.next] = elapsed
+		s.next = (s.next + 1) % statsWindow
+	}
+	return err
+}
+
+// percentile returns the nearest-rank percentile of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(p*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
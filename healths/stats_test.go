@@ -0,0 +1,64 @@
+package healths
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// flaky fails every other readiness check
+type flaky struct {
+	calls int
+}
+
+func (f *flaky) Name() string {
+	return "flaky"
+}
+
+func (f *flaky) CheckLiveness() error {
+	return nil
+}
+
+func (f *flaky) CheckReadiness() error {
+	f.calls++
+	if f.calls%2 == 0 {
+		return errors.New("down")
+	}
+	return nil
+}
+
+func TestWithStats(t *testing.T) {
+	checker := WithStats(&flaky{})
+	if checker.Name() != "flaky" {
+		t.Errorf("Expected wrapped name, got %q", checker.Name())
+	}
+
+	for i := 0; i < 10; i++ {
+		_ = checker.CheckReadiness()
+	}
+
+	stats := checker.Stats()
+	if stats.Calls != 10 || stats.Failures != 5 || stats.SuccessRate != 0.5 {
+		t.Errorf("Expected 10 calls with 50%% success, got %+v", stats)
+	}
+	if stats.P50 > stats.P95 || stats.P95 > stats.P99 {
+		t.Errorf("Expected ordered percentiles, got %+v", stats)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := make([]time.Duration, 100)
+	for i := range sorted {
+		sorted[i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	if p := percentile(sorted, 0.50); p != 50*time.Millisecond {
+		t.Errorf("Expected p50 of 50ms, got %v", p)
+	}
+	if p := percentile(sorted, 0.99); p != 99*time.Millisecond {
+		t.Errorf("Expected p99 of 99ms, got %v", p)
+	}
+	if p := percentile(nil, 0.5); p != 0 {
+		t.Errorf("Expected 0 for no samples, got %v", p)
+	}
+}
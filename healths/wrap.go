@@ -0,0 +1,25 @@
+package healths
+
+import (
+	"context"
+
+	"github.com/nduyhai/gopulse"
+)
+
+// checkLivenessContext checks the liveness of checker, through its context variant with ctx
+// when it is a gopulse.ContextHealthChecker
+func checkLivenessContext(ctx context.Context, checker gopulse.HealthChecker) error {
+	if c, ok := checker.(gopulse.ContextHealthChecker); ok {
+		return c.CheckLivenessContext(ctx)
+	}
+	return checker.CheckLiveness()
+}
+
+// checkReadinessContext checks the readiness of checker, through its context variant with ctx
+// when it is a gopulse.ContextHealthChecker
+func checkReadinessContext(ctx context.Context, checker gopulse.HealthChecker) error {
+	if c, ok := checker.(gopulse.ContextHealthChecker); ok {
+		return c.CheckReadinessContext(ctx)
+	}
+	return checker.CheckReadiness()
+}
@@ -0,0 +1,100 @@
+package healths
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nduyhai/gopulse"
+)
+
+// contextStub is a context-aware checker reporting details
+type contextStub struct {
+	stubChecker
+}
+
+func (c *contextStub) CheckLivenessContext(ctx context.Context) error {
+	return nil
+}
+
+func (c *contextStub) CheckReadinessContext(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (c *contextStub) Details() map[string]string {
+	return map[string]string{"pool": "4/10"}
+}
+
+func TestWrappersKeepOptionalInterfaces(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, tc := range []struct {
+		checker interface {
+			gopulse.HealthChecker
+			gopulse.ContextHealthChecker
+		}
+		wantErr bool
+	}{
+		{WithStats(&contextStub{stubChecker{name: "db"}}), true},
+		{ScheduledChecker(&contextStub{stubChecker{name: "db"}}, Schedule{
+			Windows: []Window{{Start: 0, End: 24 * time.Hour}},
+		}), true},
+		{Not(&contextStub{stubChecker{name: "db"}}), false},
+	} {
+		// The wrapper's own context variant keeps its semantics, e.g. Not inverting
+		if err := tc.checker.CheckReadinessContext(canceled); (err != nil) != tc.wantErr {
+			t.Errorf("%T: expected error %v, got %v", tc.checker, tc.wantErr, err)
+		}
+
+		ha := gopulse.NewHealthAggregator(context.Background())
+		ha.RegisterHealthCheck(tc.checker, gopulse.PriorityCritical)
+		ha.Start()
+		ha.UpdateHealth(tc.checker, nil, nil)
+		time.Sleep(50 * time.Millisecond)
+		checks := ha.DetailedReadinessResponse().Checks
+		ha.Stop()
+		if len(checks) != 1 || checks[0].Details["pool"] != "4/10" {
+			t.Errorf("%T: expected the wrapped checker's details, got %+v", tc.checker, checks)
+		}
+	}
+
+	if And(&stubChecker{name: "a"}, &contextStub{stubChecker{name: "b"}}).Details() != nil {
+		t.Error("Expected no details for several operands")
+	}
+}
+
+// staleStub is a checker whose data is an hour old
+type staleStub struct {
+	stubChecker
+}
+
+func (s *staleStub) DataAge() time.Duration {
+	return time.Hour
+}
+
+func TestWrappersFreshness(t *testing.T) {
+	for _, tc := range []struct {
+		checker   gopulse.HealthChecker
+		wantReady bool
+	}{
+		// Statistics passes the data age on
+		{WithStats(&staleStub{stubChecker{name: "cache"}}), false},
+		// Outside its windows, Scheduled holds its result however old the data is
+		{ScheduledChecker(&staleStub{stubChecker{name: "cache"}}, Schedule{}), true},
+		// The operand's data age says nothing about the inverted result
+		{Not(&staleStub{stubChecker{name: "cache", err: errors.New("down")}}), true},
+	} {
+		ha := gopulse.NewHealthAggregator(context.Background(), gopulse.WithMaxDataAge(time.Minute))
+		ha.RegisterHealthCheck(tc.checker, gopulse.PriorityCritical)
+		ha.Start()
+		ha.UpdateHealth(tc.checker, tc.checker.CheckLiveness(), tc.checker.CheckReadiness())
+		time.Sleep(50 * time.Millisecond)
+		ready, errs := ha.GetReadiness()
+		ha.Stop()
+		if ready != tc.wantReady {
+			t.Errorf("%T: expected ready %v, got %v (%v)", tc.checker, tc.wantReady, ready, errs)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Package healthtest provides helpers for testing health checkers and the services using them
+package healthtest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+// Response is one scripted reply of an HTTPServer
+type Response struct {
+	// Status code to reply with, zero means 200
+	Status int
+	// Body to write
+	Body string
+	// Delay before replying, e.g. to exercise checker timeouts
+	Delay time.Duration
+}
+
+// HTTPServer is an httptest.Server replying with scripted responses, in order. Once the
+// script is exhausted the last response is repeated; with no responses it replies 200.
+type HTTPServer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	responses []Response
+	requests  int
+}
+
+// NewHTTPServer starts an HTTPServer replying with the given responses. Close it when done.
+func NewHTTPServer(responses ...Response) *HTTPServer {
+	s := &HTTPServer{responses: responses}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Requests returns how many requests the server has received
+func (s *HTTPServer) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// serve replies with the next scripted response
+func (s *HTTPServer) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	var response Response
+	if len(s.responses) > 0 {
+		response = s.responses[min(s.requests, len(s.responses)-1)]
+	}
+	s.requests++
+	s.mu.Unlock()
+
+	if response.Delay > 0 {
+		select {
+		case <-time.After(response.Delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	status := response.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(response.Body))
+}
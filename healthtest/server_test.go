@@ -0,0 +1,38 @@
+package healthtest
+
+import (
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestHTTPServerScript(t *testing.T) {
+	server := NewHTTPServer(
+		Response{Status: http.StatusServiceUnavailable, Body: "starting"},
+		Response{Body: "ok"},
+	)
+	defer server.Close()
+
+	for i, want := range []struct {
+		status int
+		body   string
+	}{
+		{http.StatusServiceUnavailable, "starting"},
+		{http.StatusOK, "ok"},
+		{http.StatusOK, "ok"},
+	} {
+		resp, err := http.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if resp.StatusCode != want.status || string(body) != want.body {
+			t.Errorf("Request %d: expected %d %q, got %d %q", i, want.status, want.body, resp.StatusCode, body)
+		}
+	}
+
+	if n := server.Requests(); n != 3 {
+		t.Errorf("Expected 3 requests, got %d", n)
+	}
+}
@@ -0,0 +1,102 @@
+package gopulse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// ErrStabilizing is reported as the readiness error of a newly started service until its checks
+// have passed for the stabilization period
+var ErrStabilizing = errors.New("stabilizing")
+
+// stabilizingCheckName is the key of the stabilization error in readiness error maps
+const stabilizingCheckName = "stabilizing"
+
+// stabilizationError returns the readiness error while the checks pass but haven't yet for
+// the stabilization period. Failing checks are left to report themselves.
+func (ha *HealthAggregator) stabilizationError() error {
+	if ha.stabilized.Load() {
+		return nil
+	}
+	if ready, _ := ha.aggregate(ha.statusReadiness, nil); !ready {
+		return nil
+	}
+	return fmt.Errorf("%w: checks must pass for %s after startup", ErrStabilizing, ha.config.StabilizationPeriod)
+}
+
+// trackStabilization marks the service stabilized once the checks have passed without
+// interruption for the stabilization period. It runs on the update goroutine, on every
+// update and readiness poll.
+func (ha *HealthAggregator) trackStabilization() {
+	if ha.stabilized.Load() {
+		return
+	}
+	if ready, _ := ha.aggregate(ha.statusReadiness, nil); !ready {
+		ha.passingStreak = false
+		return
+	}
+	now := ha.config.Clock.Monotonic()
+	if !ha.passingStreak {
+		ha.passingStreak = true
+		ha.passingSince = now
+	}
+	if now-ha.passingSince >= ha.config.StabilizationPeriod {
+		ha.stabilized.Store(true)
+		ha.logger().Info("checks stable, reporting ready", "period", ha.config.StabilizationPeriod)
+	}
+}
+
+// BeginShutdown reports not ready from now on, as draining, while liveness and checks keep
+// working, so the service is removed from load balancer endpoints before it stops serving.
+// ShutdownOnSignal calls it on SIGTERM; call it directly with custom signal handling.
+func (ha *HealthAggregator) BeginShutdown() {
+	if !ha.shuttingDown.Swap(true) {
+		ha.logger().Info("shutting down, reporting not ready")
+	}
+}
+
+// ShutdownOnSignal returns a context canceled once the service should stop serving: on the
+// first of signals (SIGTERM and interrupt by default), readiness goes down with BeginShutdown,
+// and the context is canceled PreStopReadinessDelay later, giving the load balancer time to
+// stop routing new requests. The context is also canceled when the aggregator stops.
+//
+//	ctx := ha.ShutdownOnSignal()
+//	<-ctx.Done()
+//	_ = server.Shutdown(context.Background())
+//	ha.Stop()
+func (ha *HealthAggregator) ShutdownOnSignal(signals ...os.Signal) context.Context {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGTERM, os.Interrupt}
+	}
+	received := make(chan os.Signal, 1)
+	signal.Notify(received, signals...)
+
+	ctx, cancel := context.WithCancel(ha.ctx)
+	ha.wg.Add(1)
+	go func() {
+		defer ha.wg.Done()
+		defer cancel()
+		defer signal.Stop(received)
+
+		select {
+		case <-ha.ctx.Done():
+			return
+		case sig := <-received:
+			ha.logger().Info("received shutdown signal", "signal", sig, "delay", ha.config.PreStopReadinessDelay)
+		}
+		ha.BeginShutdown()
+
+		timer := time.NewTimer(ha.config.PreStopReadinessDelay)
+		defer timer.Stop()
+		select {
+		case <-ha.ctx.Done():
+		case <-timer.C:
+		}
+	}()
+	return ctx
+}
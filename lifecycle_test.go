@@ -0,0 +1,76 @@
+package gopulse
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStabilizationPeriod(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx, WithStabilizationPeriod(200*time.Millisecond))
+	checker := &mockHealthChecker{name: "db"}
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	// Failing checks report themselves rather than the stabilization
+	ha.UpdateHealth(checker, nil, errors.New("timeout"))
+	time.Sleep(50 * time.Millisecond)
+	if ready, errs := ha.GetReadiness(); ready || errs["db"] == nil {
+		t.Errorf("Expected the failing check to be reported, got %v %v", ready, errs)
+	}
+
+	ha.UpdateHealth(checker, nil, nil)
+	time.Sleep(50 * time.Millisecond)
+	ready, errs := ha.GetReadiness()
+	if ready || !errors.Is(errs["stabilizing"], ErrStabilizing) {
+		t.Errorf("Expected passing checks to stabilize first, got %v %v", ready, errs)
+	}
+	if response := ha.ReadinessResponse(); response.Maintenance || response.Status != StatusDown {
+		t.Errorf("Expected a DOWN response not flagged as maintenance, got %+v", response)
+	}
+	if live, _ := ha.GetLiveness(); !live {
+		t.Error("Expected liveness to be unaffected by stabilization")
+	}
+
+	// Stabilization is checked on the next update or readiness poll
+	waitFor(t, 3*time.Second, func() bool {
+		ready, _ := ha.GetReadiness()
+		return ready
+	})
+
+	// It only applies at startup
+	ha.UpdateHealth(checker, nil, errors.New("timeout"))
+	time.Sleep(50 * time.Millisecond)
+	ha.UpdateHealth(checker, nil, nil)
+	time.Sleep(50 * time.Millisecond)
+	if ready, errs := ha.GetReadiness(); !ready {
+		t.Errorf("Expected readiness to follow the checks once stabilized, got %v", errs)
+	}
+}
+
+func TestBeginShutdown(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	checker := &mockHealthChecker{name: "db"}
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(checker, nil, nil)
+	time.Sleep(50 * time.Millisecond)
+	ha.BeginShutdown()
+
+	ready, errs := ha.GetReadiness()
+	if ready || !errors.Is(errs["draining"], ErrDraining) {
+		t.Errorf("Expected shutdown to fail readiness, got %v %v", ready, errs)
+	}
+	if live, _ := ha.GetLiveness(); !live {
+		t.Error("Expected liveness to be unaffected by shutdown")
+	}
+	if !ha.Draining() {
+		t.Error("Expected Draining to be true")
+	}
+}
@@ -0,0 +1,36 @@
+//go:build unix
+
+package gopulse
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestShutdownOnSignal(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx, WithPreStopReadinessDelay(200*time.Millisecond))
+	ha.Start()
+	defer ha.Stop()
+
+	shutdown := ha.ShutdownOnSignal(syscall.SIGUSR1)
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, time.Second, ha.Draining)
+
+	// Still serving during the pre-stop delay
+	if shutdown.Err() != nil {
+		t.Error("Expected the context to stay open during the pre-stop delay")
+	}
+	select {
+	case <-shutdown.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the context to be canceled after the pre-stop delay")
+	}
+	if ready, _ := ha.GetReadiness(); ready {
+		t.Error("Expected readiness to stay down after the delay")
+	}
+}
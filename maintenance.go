@@ -0,0 +1,57 @@
+package gopulse
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrMaintenance is reported as the readiness error while maintenance mode is on
+var ErrMaintenance = errors.New("maintenance")
+
+// maintenanceCheckName is the key of the maintenance error in readiness error maps
+const maintenanceCheckName = "maintenance"
+
+// defaultMaintenanceReason is used when SetMaintenance is called without a reason
+const defaultMaintenanceReason = "scheduled maintenance"
+
+// SetMaintenance puts the service in maintenance mode: readiness reports down regardless of
+// the checks, and responses say it's planned maintenance rather than a failure. Liveness is
+// not affected.
+func (ha *HealthAggregator) SetMaintenance(reason string) {
+	if reason == "" {
+		reason = defaultMaintenanceReason
+	}
+	ha.maintenance.Store(&reason)
+}
+
+// ClearMaintenance ends maintenance mode, so readiness reflects the checks again
+func (ha *HealthAggregator) ClearMaintenance() {
+	ha.maintenance.Store(nil)
+}
+
+// readinessOverride returns the readiness error forcing the service down regardless of its
+// checks, in maintenance mode, while draining or until stabilized, with the key it is
+// reported under in readiness error maps; "" and nil otherwise
+func (ha *HealthAggregator) readinessOverride() (string, error) {
+	if reason := ha.maintenance.Load(); reason != nil {
+		return maintenanceCheckName, fmt.Errorf("%w: %s", ErrMaintenance, *reason)
+	}
+	if err := ha.drainError(); err != nil {
+		return drainingCheckName, err
+	}
+	if err := ha.stabilizationError(); err != nil {
+		return stabilizingCheckName, err
+	}
+	return "", nil
+}
+
+// overrideResult builds the readiness result for a readinessOverride. Only maintenance mode
+// is flagged as planned maintenance.
+func (ha *HealthAggregator) overrideResult(name string, err error) *pulseResult {
+	return &pulseResult{
+		status:      StatusDown,
+		reason:      err.Error(),
+		maintenance: name == maintenanceCheckName,
+		build:       ha.buildInfo,
+	}
+}
@@ -0,0 +1,51 @@
+package gopulse
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMaintenanceMode(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	checker := &mockHealthChecker{name: "db"}
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(checker, nil, nil)
+	time.Sleep(50 * time.Millisecond)
+
+	ha.SetMaintenance("database migration")
+
+	ready, errs := ha.GetReadiness()
+	if ready || !errors.Is(errs["maintenance"], ErrMaintenance) {
+		t.Errorf("Expected maintenance to fail readiness, got %v %v", ready, errs)
+	}
+	if live, _ := ha.GetLiveness(); !live {
+		t.Error("Expected liveness to be unaffected by maintenance")
+	}
+
+	response := ha.ReadinessResponse()
+	if response.Status != StatusDown || !response.Maintenance || response.Reason != "maintenance: database migration" {
+		t.Errorf("Unexpected maintenance response %+v", response)
+	}
+	if summary := ha.Summary(); summary != "maintenance: database migration" {
+		t.Errorf("Unexpected summary %q", summary)
+	}
+
+	ha.ClearMaintenance()
+	if ready, _ := ha.GetReadiness(); !ready {
+		t.Error("Expected readiness to reflect checks after maintenance")
+	}
+	if response := ha.ReadinessResponse(); response.Maintenance {
+		t.Error("Expected maintenance flag to be cleared")
+	}
+
+	ha.SetMaintenance("")
+	if summary := ha.Summary(); summary != "maintenance: scheduled maintenance" {
+		t.Errorf("Expected default reason, got %q", summary)
+	}
+}
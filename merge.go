@@ -0,0 +1,64 @@
+package gopulse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// statusSeverity ranks statuses for merging, the most severe winning
+var statusSeverity = map[Status]int{
+	StatusUp:       0,
+	StatusInactive: 0,
+	StatusDegraded: 1,
+	StatusUnknown:  2,
+	StatusDown:     3,
+}
+
+// MergeResponses combines several PulseResponses, e.g. the local one and those of downstream
+// gopulse endpoints, into one. The merged status is the most severe one: DOWN if any is down,
+// then UNKNOWN, then DEGRADED, else UP. Details are merged; a name present in more than one
+// response is namespaced by the 1-based position of each response, as in "2/db". Reasons are
+// joined, omitted counts summed, and the build info of the first response carrying one is
+// kept. nil responses are skipped.
+func MergeResponses(responses ...*PulseResponse) *PulseResponse {
+	merged := NewUpStatus()
+
+	seen := make(map[string]int)
+	for _, response := range responses {
+		if response == nil {
+			continue
+		}
+		for name := range response.Details {
+			seen[name]++
+		}
+	}
+
+	var reasons []string
+	for i, response := range responses {
+		if response == nil {
+			continue
+		}
+		if statusSeverity[response.Status] > statusSeverity[merged.Status] {
+			merged.Status = response.Status
+		}
+		for name, status := range response.Details {
+			if seen[name] > 1 {
+				name = fmt.Sprintf("%d/%s", i+1, name)
+			}
+			if merged.Details == nil {
+				merged.Details = make(map[string]Status)
+			}
+			merged.Details[name] = status
+		}
+		if response.Reason != "" {
+			reasons = append(reasons, response.Reason)
+		}
+		merged.Omitted += response.Omitted
+		merged.Maintenance = merged.Maintenance || response.Maintenance
+		if merged.Build == nil {
+			merged.Build = response.Build
+		}
+	}
+	merged.Reason = strings.Join(reasons, "; ")
+	return merged
+}
@@ -0,0 +1,52 @@
+package gopulse
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestMergeResponses(t *testing.T) {
+	local := &PulseResponse{
+		Status:  StatusDegraded,
+		Reason:  "1 low check degraded: cache",
+		Details: map[string]Status{"cache": StatusDegraded, "db": StatusUp},
+		Build:   &BuildInfo{Version: "v1.2.0"},
+	}
+	remote := &PulseResponse{
+		Status:  StatusDown,
+		Reason:  "1 critical check failing: db (timeout)",
+		Details: map[string]Status{"db": StatusDown, "queue": StatusUp},
+		Omitted: 2,
+	}
+
+	merged := MergeResponses(local, nil, remote)
+	if merged.Status != StatusDown {
+		t.Errorf("Expected DOWN when any response is down, got %s", merged.Status)
+	}
+	want := map[string]Status{"cache": StatusDegraded, "1/db": StatusUp, "3/db": StatusDown, "queue": StatusUp}
+	if !maps.Equal(merged.Details, want) {
+		t.Errorf("Expected details %v, got %v", want, merged.Details)
+	}
+	if merged.Reason != local.Reason+"; "+remote.Reason || merged.Omitted != 2 || merged.Build != local.Build {
+		t.Errorf("Unexpected merged response %+v", merged)
+	}
+
+	for _, tc := range []struct {
+		statuses []Status
+		want     Status
+	}{
+		{nil, StatusUp},
+		{[]Status{StatusUp, StatusUp}, StatusUp},
+		{[]Status{StatusUp, StatusDegraded}, StatusDegraded},
+		{[]Status{StatusDegraded, StatusUnknown}, StatusUnknown},
+		{[]Status{StatusDown, StatusUnknown}, StatusDown},
+	} {
+		var responses []*PulseResponse
+		for _, status := range tc.statuses {
+			responses = append(responses, &PulseResponse{Status: status})
+		}
+		if got := MergeResponses(responses...).Status; got != tc.want {
+			t.Errorf("MergeResponses(%v) = %s, want %s", tc.statuses, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package gopulse
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MetricsHandler serves per-check metrics in the Prometheus text exposition format:
+//
+//	gopulse_check_last_success_seconds{check="db"} 1.7e+09
+//
+// is the Unix time the check last passed, or 0 if it never did. Checks registered with tags
+// also get a tags label listing them, comma-separated. Alerting on its age catches
+// a check that keeps reporting but hasn't succeeded in a while.
+//
+// With auto-update, gopulse_check_run_interval_seconds and gopulse_check_scheduling_lag_seconds
+// expose Stats, revealing when slow checks keep CheckInterval from being honored.
+func (ha *HealthAggregator) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		ha.writeMetrics(w)
+	})
+}
+
+// writeMetrics writes the metrics of every check, sorted by name
+func (ha *HealthAggregator) writeMetrics(w io.Writer) {
+	statuses := ha.Snapshot()
+
+	fmt.Fprintln(w, "# HELP gopulse_check_last_success_seconds Unix time the check last passed, 0 if it never did.")
+	fmt.Fprintln(w, "# TYPE gopulse_check_last_success_seconds gauge")
+	for _, name := range slices.Sorted(maps.Keys(statuses)) {
+		var seconds float64
+		if success := statuses[name].LastSuccess; !success.IsZero() {
+			seconds = float64(success.UnixNano()) / 1e9
+		}
+		fmt.Fprintf(w, "gopulse_check_last_success_seconds{%s} %s\n",
+			checkLabels(name, statuses[name]), strconv.FormatFloat(seconds, 'g', -1, 64))
+	}
+
+	scheduling := ha.Stats()
+	writeSchedulingGauge(w, "gopulse_check_run_interval_seconds",
+		"Time between the last two completed auto-update checks.", statuses, scheduling,
+		func(stats SchedulingStats) time.Duration { return stats.Interval })
+	writeSchedulingGauge(w, "gopulse_check_scheduling_lag_seconds",
+		"How late the last auto-update check started compared to its scheduled time.", statuses, scheduling,
+		func(stats SchedulingStats) time.Duration { return stats.Lag })
+}
+
+// writeSchedulingGauge writes a gauge of one SchedulingStats duration for every check that
+// has run in the background
+func writeSchedulingGauge(w io.Writer, metric, help string, statuses map[string]*HealthStatus,
+	scheduling map[string]SchedulingStats, value func(SchedulingStats) time.Duration) {
+	fmt.Fprintf(w, "# HELP %s %s\n", metric, help)
+	fmt.Fprintf(w, "# TYPE %s gauge\n", metric)
+	for _, name := range slices.Sorted(maps.Keys(scheduling)) {
+		status, ok := statuses[name]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(w, "%s{%s} %s\n", metric, checkLabels(name, status),
+			strconv.FormatFloat(value(scheduling[name]).Seconds(), 'g', -1, 64))
+	}
+}
+
+// checkLabels returns the labels of a check's metrics: its name, and its tags joined by
+// commas when it has any
+func checkLabels(name string, status *HealthStatus) string {
+	labels := "check=" + quoteLabel(name)
+	if len(status.Tags) > 0 {
+		labels += ",tags=" + quoteLabel(strings.Join(status.Tags, ","))
+	}
+	return labels
+}
+
+// labelEscaper escapes label values as required by the Prometheus text format
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// quoteLabel returns a quoted Prometheus label value
+func quoteLabel(value string) string {
+	return `"` + labelEscaper.Replace(value) + `"`
+}
@@ -0,0 +1,48 @@
+package gopulse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLastSuccess(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{wall: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	ha := NewHealthAggregator(ctx, WithClock(clock), WithExpiryTime(time.Hour))
+	db := &mockHealthChecker{name: "db"}
+	cache := &mockHealthChecker{name: `cache "eu"`}
+	ha.RegisterHealthCheck(db, PriorityCritical)
+	ha.RegisterHealthCheck(cache, PriorityLow)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(db, nil, nil)
+	ha.UpdateHealth(cache, nil, errors.New("down"))
+	time.Sleep(50 * time.Millisecond)
+
+	// A failure later on keeps the time of the last success
+	clock.Advance(time.Minute, time.Minute)
+	ha.UpdateHealth(db, nil, errors.New("down"))
+	time.Sleep(50 * time.Millisecond)
+	if success := ha.Snapshot()["db"].LastSuccess; !success.Equal(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the last success to be kept through a failure, got %v", success)
+	}
+
+	recorder := httptest.NewRecorder()
+	ha.MetricsHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := recorder.Body.String()
+	for _, want := range []string{
+		"# TYPE gopulse_check_last_success_seconds gauge\n",
+		`gopulse_check_last_success_seconds{check="cache \"eu\""} 0` + "\n",
+		`gopulse_check_last_success_seconds{check="db"} 1.7041104e+09` + "\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", want, body)
+		}
+	}
+}
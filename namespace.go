@@ -0,0 +1,40 @@
+package gopulse
+
+// namespaced prefixes the name of a wrapped checker
+type namespaced struct {
+	HealthChecker
+	name string
+}
+
+// Namespaced wraps checker so its name becomes "prefix/name", letting independently developed
+// modules register e.g. "auth/database" and "billing/database" into one aggregator without
+// colliding. Optional interfaces of checker, such as DetailProvider, keep working.
+func Namespaced(prefix string, checker HealthChecker) HealthChecker {
+	return &namespaced{HealthChecker: checker, name: prefix + "/" + checker.Name()}
+}
+
+// Name returns the prefixed name
+func (n *namespaced) Name() string {
+	return n.name
+}
+
+// Unwrap returns the wrapped checker
+func (n *namespaced) Unwrap() HealthChecker {
+	return n.HealthChecker
+}
+
+// checkerAs finds the first checker in the Unwrap chain of checker implementing T, so
+// optional interfaces survive wrapping
+func checkerAs[T any](checker HealthChecker) (T, bool) {
+	for {
+		if target, ok := checker.(T); ok {
+			return target, true
+		}
+		wrapper, ok := checker.(interface{ Unwrap() HealthChecker })
+		if !ok {
+			var zero T
+			return zero, false
+		}
+		checker = wrapper.Unwrap()
+	}
+}
@@ -0,0 +1,38 @@
+package gopulse
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNamespaced(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	auth := Namespaced("auth", &replicaChecker{mockHealthChecker{name: "database"}})
+	billing := Namespaced("billing", &mockHealthChecker{name: "database"})
+	ha.RegisterHealthCheck(auth, PriorityCritical)
+	ha.RegisterHealthCheck(billing, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(auth, nil, nil)
+	ha.UpdateHealth(billing, nil, errors.New("timeout"))
+	time.Sleep(100 * time.Millisecond)
+
+	response := ha.DetailedReadinessResponse()
+	if len(response.Checks) != 2 {
+		t.Fatalf("Expected both checks to be registered, got %+v", response.Checks)
+	}
+	byName := make(map[string]CheckDetail)
+	for _, check := range response.Checks {
+		byName[check.Name] = check
+	}
+	if check := byName["auth/database"]; check.Status != StatusUp || check.Details["replica_lag"] != "1.2s" {
+		t.Errorf("Expected auth/database up with the wrapped checker's details, got %+v", check)
+	}
+	if check := byName["billing/database"]; check.Status != StatusDown {
+		t.Errorf("Expected billing/database down, got %+v", check)
+	}
+}
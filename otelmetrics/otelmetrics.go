@@ -0,0 +1,88 @@
+// Package otelmetrics records gopulse check results as OpenTelemetry metrics: each check's
+// status as the gopulse.check.status observable gauge (1 when live and ready, 0 otherwise) and
+// its duration as the gopulse.check.duration histogram, both with a check attribute.
+//
+// It is a separate module, so gopulse itself stays free of dependencies:
+//
+//	ha := gopulse.NewHealthAggregator(ctx, otelmetrics.WithOtelMeter(provider.Meter("gopulse")))
+package otelmetrics
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/nduyhai/gopulse"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// checkKey is the attribute naming the check of a measurement
+const checkKey = attribute.Key("check")
+
+// WithOtelMeter records every check result through meter. The status gauge reports the last
+// result of each registered check; removed checks are dropped from it. Errors creating the instruments are passed to
+// otel.Handle and leave the failing instrument unrecorded.
+func WithOtelMeter(meter metric.Meter) gopulse.Option {
+	r := &recorder{up: make(map[string]int64)}
+
+	duration, err := meter.Float64Histogram("gopulse.check.duration",
+		metric.WithDescription("Duration of health checks"), metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+	} else {
+		r.duration = duration
+	}
+	_, err = meter.Int64ObservableGauge("gopulse.check.status",
+		metric.WithDescription("Whether the health check is live and ready (1) or not (0)"),
+		metric.WithInt64Callback(r.observe))
+	if err != nil {
+		otel.Handle(err)
+	}
+	return gopulse.WithResultObserver(r.record)
+}
+
+// recorder keeps the last status of every check for the gauge
+type recorder struct {
+	duration metric.Float64Histogram
+
+	mu sync.Mutex
+	up map[string]int64
+}
+
+// record stores a check result and records its duration, or forgets a removed check
+func (r *recorder) record(name string, status *gopulse.HealthStatus) {
+	if status == nil {
+		r.mu.Lock()
+		delete(r.up, name)
+		r.mu.Unlock()
+		return
+	}
+
+	var up int64
+	if status.Liveness && status.Readiness {
+		up = 1
+	}
+	r.mu.Lock()
+	r.up[name] = up
+	r.mu.Unlock()
+
+	if r.duration != nil {
+		r.duration.Record(context.Background(), status.Duration.Seconds(),
+			metric.WithAttributes(checkKey.String(name)))
+	}
+}
+
+// observe reports the last status of every check, sorted by name
+func (r *recorder) observe(_ context.Context, observer metric.Int64Observer) error {
+	r.mu.Lock()
+	up := maps.Clone(r.up)
+	r.mu.Unlock()
+
+	for _, name := range slices.Sorted(maps.Keys(up)) {
+		observer.Observe(up[name], metric.WithAttributes(checkKey.String(name)))
+	}
+	return nil
+}
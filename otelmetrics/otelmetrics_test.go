@@ -0,0 +1,78 @@
+package otelmetrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nduyhai/gopulse"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestWithOtelMeter(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	ha := gopulse.NewHealthAggregator(context.Background(), WithOtelMeter(provider.Meter("gopulse")))
+	db := gopulse.Namespaced("app", &stubChecker{name: "db"})
+	cache := &stubChecker{name: "cache"}
+	ha.RegisterHealthCheck(db, gopulse.PriorityCritical)
+	ha.RegisterHealthCheck(cache, gopulse.PriorityLow)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(db, nil, nil)
+	ha.UpdateHealth(cache, nil, errors.New("timeout"))
+	time.Sleep(100 * time.Millisecond)
+
+	observed, durations := collect(t, reader)
+	if observed["app/db"] != 1 || observed["cache"] != 0 || len(observed) != 2 {
+		t.Errorf("Expected app/db up and cache down, got %v", observed)
+	}
+	if durations["app/db"] != 1 || durations["cache"] != 1 {
+		t.Errorf("Expected one duration per result, got %v", durations)
+	}
+
+	ha.UnregisterHealthCheck("cache")
+	if observed, _ := collect(t, reader); len(observed) != 1 || observed["app/db"] != 1 {
+		t.Errorf("Expected the removed check to be dropped from the gauge, got %v", observed)
+	}
+}
+
+// collect reads the last status and the number of durations recorded per check
+func collect(t *testing.T, reader sdkmetric.Reader) (map[string]int64, map[string]uint64) {
+	t.Helper()
+	var data metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &data); err != nil {
+		t.Fatal(err)
+	}
+	observed := make(map[string]int64)
+	durations := make(map[string]uint64)
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			switch agg := m.Data.(type) {
+			case metricdata.Gauge[int64]:
+				for _, point := range agg.DataPoints {
+					check, _ := point.Attributes.Value(checkKey)
+					observed[check.AsString()] = point.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, point := range agg.DataPoints {
+					check, _ := point.Attributes.Value(checkKey)
+					durations[check.AsString()] = point.Count
+				}
+			}
+		}
+	}
+	return observed, durations
+}
+
+// stubChecker is a HealthChecker whose results are pushed with UpdateHealth
+type stubChecker struct {
+	name string
+}
+
+func (s *stubChecker) Name() string          { return s.name }
+func (s *stubChecker) CheckLiveness() error  { return nil }
+func (s *stubChecker) CheckReadiness() error { return nil }
@@ -0,0 +1,82 @@
+package gopulse
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// RecordFormat is the line format of the result recorder
+type RecordFormat int
+
+const (
+	// RecordJSONL writes one JSON object per line
+	RecordJSONL RecordFormat = iota
+	// RecordCSV writes comma-separated values, starting with a header row
+	RecordCSV
+)
+
+// resultRecord is a single check result written by the result recorder
+type resultRecord struct {
+	Time       time.Time `json:"time"`
+	Name       string    `json:"name"`
+	Liveness   bool      `json:"liveness"`
+	Readiness  bool      `json:"readiness"`
+	DurationMs float64   `json:"duration_ms"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// recordHeader is the header row of CSV records
+var recordHeader = []string{"time", "name", "liveness", "readiness", "duration_ms", "error"}
+
+// newResultRecorder creates the background writer of check results, queuing the CSV header
+func newResultRecorder(config *Config) *asyncWriter {
+	recorder := newAsyncWriter(config.ResultRecorder, auditBufferSize)
+	if config.RecordFormat == RecordCSV {
+		recorder.write(csvLine(recordHeader))
+	}
+	return recorder
+}
+
+// recordResult appends a check result to the result recorder
+func (ha *HealthAggregator) recordResult(name string, status *HealthStatus) {
+	record := resultRecord{
+		Time:       status.LastUpdate,
+		Name:       name,
+		Liveness:   status.Liveness,
+		Readiness:  status.Readiness,
+		DurationMs: float64(status.Duration) / float64(time.Millisecond),
+	}
+	if err := errors.Join(status.LivenessErr, status.ReadinessErr); err != nil {
+		record.Error = err.Error()
+	}
+
+	if ha.config.RecordFormat == RecordCSV {
+		ha.recorder.write(csvLine([]string{
+			record.Time.Format(time.RFC3339Nano),
+			record.Name,
+			strconv.FormatBool(record.Liveness),
+			strconv.FormatBool(record.Readiness),
+			strconv.FormatFloat(record.DurationMs, 'f', -1, 64),
+			record.Error,
+		}))
+		return
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	ha.recorder.write(append(line, '\n'))
+}
+
+// csvLine formats a CSV row, quoting fields as needed
+func csvLine(fields []string) []byte {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(fields)
+	w.Flush()
+	return buf.Bytes()
+}
@@ -0,0 +1,61 @@
+package gopulse
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResultRecorder(t *testing.T) {
+	ctx := context.Background()
+	for _, format := range []RecordFormat{RecordJSONL, RecordCSV} {
+		var buf syncBuffer
+		ha := NewHealthAggregator(ctx, WithResultRecorder(&buf, format))
+		checker := &mockHealthChecker{name: "db"}
+		ha.RegisterHealthCheck(checker, PriorityCritical)
+		ha.Start()
+
+		// Let each update apply, as pending updates of a checker are coalesced
+		ha.UpdateHealth(checker, nil, nil)
+		time.Sleep(20 * time.Millisecond)
+		ha.UpdateHealth(checker, nil, errors.New("connection refused, retrying"))
+		time.Sleep(50 * time.Millisecond)
+		ha.Stop()
+
+		var records []resultRecord
+		if format == RecordCSV {
+			rows, err := csv.NewReader(strings.NewReader(buf.String())).ReadAll()
+			if err != nil {
+				t.Fatalf("Expected valid CSV, got %v", err)
+			}
+			if len(rows) != 3 || strings.Join(rows[0], ",") != "time,name,liveness,readiness,duration_ms,error" {
+				t.Fatalf("Expected a header and 2 rows, got %q", rows)
+			}
+			for _, row := range rows[1:] {
+				records = append(records, resultRecord{Name: row[1], Readiness: row[3] == "true", Error: row[5]})
+			}
+		} else {
+			for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+				var record resultRecord
+				if err := json.Unmarshal([]byte(line), &record); err != nil {
+					t.Fatalf("Expected a JSON line, got %q: %v", line, err)
+				}
+				records = append(records, record)
+			}
+		}
+
+		if len(records) != 2 {
+			t.Fatalf("Expected 2 records, got %+v", records)
+		}
+		if records[0].Name != "db" || !records[0].Readiness || records[0].Error != "" {
+			t.Errorf("Unexpected first record %+v", records[0])
+		}
+		if records[1].Readiness || records[1].Error != "connection refused, retrying" {
+			t.Errorf("Unexpected second record %+v", records[1])
+		}
+	}
+}
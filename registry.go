@@ -0,0 +1,101 @@
+package gopulse
+
+import (
+	"sort"
+	"sync"
+)
+
+// Registry holds named HealthAggregators, e.g. one per subsystem, and rolls them
+// up into a combined top-level health view
+type Registry struct {
+	mu          sync.RWMutex
+	aggregators map[string]*HealthAggregator
+}
+
+// DefaultRegistry is a process-wide Registry, so aggregators don't need to be threaded through the codebase
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		aggregators: make(map[string]*HealthAggregator),
+	}
+}
+
+// Register adds an aggregator under the given name, replacing any previous one
+func (r *Registry) Register(name string, ha *HealthAggregator) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.aggregators[name] = ha
+}
+
+// Unregister removes the named aggregator
+func (r *Registry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.aggregators, name)
+}
+
+// Get returns the named aggregator
+func (r *Registry) Get(name string) (*HealthAggregator, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ha, ok := r.aggregators[name]
+	return ha, ok
+}
+
+// Names returns the names of all registered aggregators in sorted order
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.aggregators))
+	for name := range r.aggregators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CombinedLiveness returns whether every registered aggregator is live. Errors are
+// keyed by "aggregator/check".
+func (r *Registry) CombinedLiveness() (bool, map[string]error) {
+	return r.combine((*HealthAggregator).GetLiveness)
+}
+
+// CombinedReadiness returns whether every registered aggregator is ready. Errors are
+// keyed by "aggregator/check".
+func (r *Registry) CombinedReadiness() (bool, map[string]error) {
+	return r.combine((*HealthAggregator).GetReadiness)
+}
+
+// combine evaluates a probe on every registered aggregator
+func (r *Registry) combine(probe func(*HealthAggregator) (bool, map[string]error)) (bool, map[string]error) {
+	r.mu.RLock()
+	aggregators := make(map[string]*HealthAggregator, len(r.aggregators))
+	for name, ha := range r.aggregators {
+		aggregators[name] = ha
+	}
+	r.mu.RUnlock()
+
+	healthy := true
+	errs := make(map[string]error)
+	for name, ha := range aggregators {
+		ok, checkErrs := probe(ha)
+		if ok {
+			continue
+		}
+		healthy = false
+		for check, err := range checkErrs {
+			errs[name+"/"+check] = err
+		}
+	}
+
+	if healthy {
+		return true, nil
+	}
+	return false, errs
+}
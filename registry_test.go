@@ -0,0 +1,60 @@
+package gopulse
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRegistryCombinedReadiness(t *testing.T) {
+	ctx := context.Background()
+	registry := NewRegistry()
+
+	payments := NewHealthAggregator(ctx)
+	db := &mockHealthChecker{name: "db"}
+	payments.RegisterHealthCheck(db, PriorityCritical)
+	payments.Start()
+	defer payments.Stop()
+
+	search := NewHealthAggregator(ctx)
+	index := &mockHealthChecker{name: "index"}
+	search.RegisterHealthCheck(index, PriorityCritical)
+	search.Start()
+	defer search.Stop()
+
+	registry.Register("payments", payments)
+	registry.Register("search", search)
+
+	payments.UpdateHealth(db, nil, nil)
+	search.UpdateHealth(index, nil, nil)
+	time.Sleep(100 * time.Millisecond)
+
+	ready, errs := registry.CombinedReadiness()
+	if !ready || len(errs) > 0 {
+		t.Errorf("Expected combined readiness, got %v", errs)
+	}
+
+	search.UpdateHealth(index, nil, errors.New("rebuilding"))
+	time.Sleep(100 * time.Millisecond)
+
+	ready, errs = registry.CombinedReadiness()
+	if ready || errs["search/index"] == nil {
+		t.Errorf("Expected search/index failure, got %v", errs)
+	}
+	if live, _ := registry.CombinedLiveness(); !live {
+		t.Error("Expected combined liveness")
+	}
+
+	if got, ok := registry.Get("payments"); !ok || got != payments {
+		t.Error("Expected to get the payments aggregator by name")
+	}
+	if names := registry.Names(); len(names) != 2 || names[0] != "payments" {
+		t.Errorf("Expected sorted names, got %v", names)
+	}
+
+	registry.Unregister("search")
+	if ready, _ := registry.CombinedReadiness(); !ready {
+		t.Error("Expected readiness after unregistering the failing aggregator")
+	}
+}
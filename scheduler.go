@@ -0,0 +1,96 @@
+package gopulse
+
+import (
+	"sync"
+	"time"
+)
+
+// Scheduler decides when auto-update runs each checker. After every check, Next is given
+// the checker's latest status and the current time and returns when to check it again, so
+// strategies such as checking failing dependencies more often, or expensive ones only at
+// specific times of day, can be plugged in. Backoff after failures still applies on top.
+type Scheduler interface {
+	Next(name string, status *HealthStatus, now time.Time) time.Time
+}
+
+// fixedInterval checks every checker at the same interval
+type fixedInterval time.Duration
+
+// FixedInterval returns the default Scheduler, checking every checker each interval
+func FixedInterval(interval time.Duration) Scheduler {
+	return fixedInterval(interval)
+}
+
+// Next returns now plus the interval
+func (f fixedInterval) Next(_ string, _ *HealthStatus, now time.Time) time.Time {
+	return now.Add(time.Duration(f))
+}
+
+// adaptiveInterval checks failing checkers more often than healthy ones
+type adaptiveInterval struct {
+	healthy time.Duration
+	failing time.Duration
+}
+
+// AdaptiveInterval returns a Scheduler that checks healthy checkers every healthy interval
+// and failing ones every failing interval, to notice recovery sooner
+func AdaptiveInterval(healthy, failing time.Duration) Scheduler {
+	return adaptiveInterval{healthy: healthy, failing: failing}
+}
+
+// Next returns now plus the interval matching the checker's health
+func (a adaptiveInterval) Next(_ string, status *HealthStatus, now time.Time) time.Time {
+	if status != nil && status.checked && (!status.Liveness || !status.Readiness) {
+		return now.Add(a.failing)
+	}
+	return now.Add(a.healthy)
+}
+
+// stabilityInterval polls each checker more slowly the longer its state stays unchanged
+type stabilityInterval struct {
+	min time.Duration
+	max time.Duration
+
+	mu     sync.Mutex
+	states map[string]stabilityState
+}
+
+// stabilityState is the last seen state and current interval of one checker
+type stabilityState struct {
+	status   Status
+	interval time.Duration
+}
+
+// StabilityInterval returns a Scheduler that checks a checker every min right after it was
+// registered or changed state, then doubles the interval on each unchanged result up to max.
+// Stable checkers cost little while flapping or recovering ones are noticed quickly. min should
+// be positive and max at least min; the aggregator warns and clamps them otherwise.
+func StabilityInterval(min, max time.Duration) Scheduler {
+	return &stabilityInterval{
+		min:    min,
+		max:    max,
+		states: make(map[string]stabilityState),
+	}
+}
+
+// Next returns now plus the checker's interval, adjusted for whether its state changed
+func (s *stabilityInterval) Next(name string, status *HealthStatus, now time.Time) time.Time {
+	current := StatusUnknown
+	if status != nil {
+		current = checkStatus(status)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, seen := s.states[name]
+	switch {
+	case !seen || current == StatusUnknown || state.status != current:
+		state.interval = s.min
+	default:
+		state.interval = min(state.interval*2, s.max)
+	}
+	state.status = current
+	s.states[name] = state
+	return now.Add(state.interval)
+}
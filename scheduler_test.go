@@ -0,0 +1,121 @@
+package gopulse
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// perCheckerScheduler checks each checker at its own interval
+type perCheckerScheduler map[string]time.Duration
+
+func (p perCheckerScheduler) Next(name string, _ *HealthStatus, now time.Time) time.Time {
+	return now.Add(p[name])
+}
+
+func TestWithScheduler(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx,
+		WithAutoUpdate(time.Second),
+		WithInitialDelay(0),
+		WithScheduler(perCheckerScheduler{"frequent": 20 * time.Millisecond, "rare": time.Hour}),
+	)
+	frequent := &mockHealthChecker{name: "frequent"}
+	rare := &mockHealthChecker{name: "rare"}
+	ha.RegisterHealthCheck(frequent, PriorityHigh)
+	ha.RegisterHealthCheck(rare, PriorityHigh)
+	ha.Start()
+	defer ha.Stop()
+
+	time.Sleep(250 * time.Millisecond)
+
+	// Each run calls both CheckLiveness and CheckReadiness
+	if runs := frequent.checkCount.Load() / 2; runs < 5 {
+		t.Errorf("Expected frequent checker to run often, got %d runs", runs)
+	}
+	if runs := rare.checkCount.Load() / 2; runs != 1 {
+		t.Errorf("Expected rare checker to run once, got %d runs", runs)
+	}
+}
+
+func TestAdaptiveInterval(t *testing.T) {
+	scheduler := AdaptiveInterval(time.Minute, 5*time.Second)
+	now := time.Now()
+
+	healthy := &HealthStatus{Liveness: true, Readiness: true, checked: true}
+	if next := scheduler.Next("test", healthy, now); next.Sub(now) != time.Minute {
+		t.Errorf("Expected healthy interval, got %v", next.Sub(now))
+	}
+
+	failing := &HealthStatus{Liveness: true, ReadinessErr: errors.New("down"), checked: true}
+	if next := scheduler.Next("test", failing, now); next.Sub(now) != 5*time.Second {
+		t.Errorf("Expected failing interval, got %v", next.Sub(now))
+	}
+
+	if next := scheduler.Next("test", &HealthStatus{}, now); next.Sub(now) != time.Minute {
+		t.Errorf("Expected healthy interval for unchecked status, got %v", next.Sub(now))
+	}
+}
+
+func TestStabilityInterval(t *testing.T) {
+	scheduler := StabilityInterval(time.Second, 5*time.Second)
+	now := time.Now()
+	up := &HealthStatus{Liveness: true, Readiness: true, checked: true}
+	down := &HealthStatus{Liveness: true, ReadinessErr: errors.New("down"), checked: true}
+
+	for i, tc := range []struct {
+		status *HealthStatus
+		want   time.Duration
+	}{
+		{up, time.Second},
+		{up, 2 * time.Second},
+		{up, 4 * time.Second},
+		{up, 5 * time.Second},
+		{up, 5 * time.Second},
+		{down, time.Second},
+		{down, 2 * time.Second},
+		{up, time.Second},
+	} {
+		if got := scheduler.Next("test", tc.status, now).Sub(now); got != tc.want {
+			t.Errorf("Step %d: expected interval %v, got %v", i, tc.want, got)
+		}
+	}
+
+	if got := scheduler.Next("other", nil, now).Sub(now); got != time.Second {
+		t.Errorf("Expected min interval for an unknown checker, got %v", got)
+	}
+}
+
+func TestStabilityIntervalValidation(t *testing.T) {
+	const checkInterval = 10 * time.Second
+	now := time.Now()
+	for _, tc := range []struct {
+		name     string
+		min, max time.Duration
+		warning  string
+		interval time.Duration
+	}{
+		{"zero min", 0, time.Minute, "min is not positive", checkInterval},
+		{"negative min", -time.Second, time.Minute, "min is not positive", checkInterval},
+		{"max below min", 2 * time.Second, time.Second, "max is shorter than min", 2 * time.Second},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var logs syncBuffer
+			scheduler := StabilityInterval(tc.min, tc.max)
+			NewHealthAggregator(context.Background(),
+				WithAutoUpdate(checkInterval),
+				WithScheduler(scheduler),
+				WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+			)
+			if !strings.Contains(logs.String(), tc.warning) {
+				t.Errorf("Expected warning %q, got %q", tc.warning, logs.String())
+			}
+			if got := scheduler.Next("test", nil, now).Sub(now); got != tc.interval {
+				t.Errorf("Expected the clamped interval %v, got %v", tc.interval, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package gopulse
+
+import (
+	"maps"
+	"time"
+)
+
+// SchedulingStats shows whether auto-update keeps up with its schedule for one checker
+type SchedulingStats struct {
+	// Runs is the number of completed auto-update checks
+	Runs int
+	// Interval is the time between the last two completed checks; well above the scheduled
+	// interval, it means slow checks are delaying the loop
+	Interval time.Duration
+	// Lag is how late the last check started compared to its scheduled time
+	Lag time.Duration
+	// LastRun is when the last check completed
+	LastRun time.Time
+}
+
+// Stats returns the auto-update scheduling stats of every checker that has completed at
+// least one background check. Checks skipped for backoff or overlap are not counted.
+func (ha *HealthAggregator) Stats() map[string]SchedulingStats {
+	ha.mu.RLock()
+	defer ha.mu.RUnlock()
+	return maps.Clone(ha.scheduling)
+}
+
+// recordRun records a completed auto-update check that was due at scheduled, started at
+// start and finished at end. A zero scheduled time marks the first run, which has no lag.
+func (ha *HealthAggregator) recordRun(name string, scheduled, start, end time.Time) {
+	ha.mu.Lock()
+	defer ha.mu.Unlock()
+	if _, ok := ha.checkers[name]; !ok {
+		return
+	}
+
+	stats := ha.scheduling[name]
+	if !stats.LastRun.IsZero() {
+		stats.Interval = end.Sub(stats.LastRun)
+	}
+	if !scheduled.IsZero() {
+		stats.Lag = max(start.Sub(scheduled), 0)
+	}
+	stats.Runs++
+	stats.LastRun = end
+	ha.scheduling[name] = stats
+}
@@ -0,0 +1,72 @@
+package gopulse
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSchedulingStats(t *testing.T) {
+	ctx := context.Background()
+	checkInterval := 20 * time.Millisecond
+	ha := NewHealthAggregator(ctx, WithAutoUpdate(checkInterval), WithInitialDelay(0))
+	slow := &slowHealthChecker{mockHealthChecker: mockHealthChecker{name: "slow"}, delay: 30 * time.Millisecond}
+	fast := &mockHealthChecker{name: "fast"}
+	ha.RegisterHealthCheck(slow, PriorityCritical)
+	ha.RegisterHealthCheck(fast, PriorityLow)
+	ha.Start()
+	defer ha.Stop()
+
+	time.Sleep(400 * time.Millisecond)
+
+	// Checkers run concurrently, so the slow checker can't keep its 20ms interval but
+	// doesn't hold up the fast one
+	stats := ha.Stats()
+	for _, name := range []string{"slow", "fast"} {
+		if s, ok := stats[name]; !ok || s.Runs < 2 {
+			t.Fatalf("Expected %s to have run repeatedly, got %+v", name, s)
+		}
+	}
+	if lag := stats["fast"].Lag; lag >= checkInterval {
+		t.Errorf("Expected the fast checker to start on time, got lag %v", lag)
+	}
+	if interval := stats["slow"].Interval; interval < 2*checkInterval {
+		t.Errorf("Expected the slow checker to miss its interval, got %v", interval)
+	}
+
+	recorder := httptest.NewRecorder()
+	ha.MetricsHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := recorder.Body.String()
+	for _, want := range []string{
+		"# TYPE gopulse_check_run_interval_seconds gauge\n",
+		`gopulse_check_run_interval_seconds{check="slow"} 0.`,
+		"# TYPE gopulse_check_scheduling_lag_seconds gauge\n",
+		`gopulse_check_scheduling_lag_seconds{check="slow"} 0.`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", want, body)
+		}
+	}
+
+	ha.UnregisterHealthCheck("slow")
+	if _, ok := ha.Stats()["slow"]; ok {
+		t.Error("Expected stats to be dropped on unregister")
+	}
+}
+
+func TestSchedulingStatsWithoutAutoUpdate(t *testing.T) {
+	ha := NewHealthAggregator(context.Background())
+	checker := &mockHealthChecker{name: "db"}
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(checker, nil, nil)
+	time.Sleep(50 * time.Millisecond)
+	if stats := ha.Stats(); len(stats) != 0 {
+		t.Errorf("Expected no scheduling stats for manual updates, got %+v", stats)
+	}
+}
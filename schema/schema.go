@@ -0,0 +1,29 @@
+// Package schema provides JSON Schemas (draft 2020-12) of the gopulse response types, for
+// clients, API gateways and contract tests to validate responses or generate types
+package schema
+
+import _ "embed"
+
+//go:embed pulse_response.json
+var pulseResponse []byte
+
+//go:embed detailed_pulse_response.json
+var detailedPulseResponse []byte
+
+//go:embed scored_response.json
+var scoredResponse []byte
+
+// PulseResponse returns the JSON Schema of gopulse.PulseResponse
+func PulseResponse() []byte {
+	return append([]byte(nil), pulseResponse...)
+}
+
+// DetailedPulseResponse returns the JSON Schema of gopulse.DetailedPulseResponse
+func DetailedPulseResponse() []byte {
+	return append([]byte(nil), detailedPulseResponse...)
+}
+
+// ScoredResponse returns the JSON Schema of gopulse.ScoredResponse
+func ScoredResponse() []byte {
+	return append([]byte(nil), scoredResponse...)
+}
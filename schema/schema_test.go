@@ -0,0 +1,82 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/nduyhai/gopulse"
+)
+
+// schemaObject is the part of a JSON Schema object the tests compare
+type schemaObject struct {
+	Required   []string                `json:"required"`
+	Properties map[string]any          `json:"properties"`
+	Defs       map[string]schemaObject `json:"$defs"`
+	Enum       []string                `json:"enum"`
+}
+
+// jsonFields returns the JSON field names of a struct type and the ones always present
+func jsonFields(t reflect.Type) (fields, required []string) {
+	for i := 0; i < t.NumField(); i++ {
+		name, opts, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		fields = append(fields, name)
+		if !strings.Contains(opts, "omit") {
+			required = append(required, name)
+		}
+	}
+	return fields, required
+}
+
+// assertMatches checks a schema object lists exactly the JSON fields of a struct type
+func assertMatches(t *testing.T, name string, object schemaObject, typ reflect.Type) {
+	t.Helper()
+	fields, required := jsonFields(typ)
+	properties := make([]string, 0, len(object.Properties))
+	for property := range object.Properties {
+		properties = append(properties, property)
+	}
+	sort.Strings(fields)
+	sort.Strings(properties)
+	sort.Strings(required)
+	sort.Strings(object.Required)
+	if !reflect.DeepEqual(fields, properties) {
+		t.Errorf("%s: schema properties %v don't match fields %v", name, properties, fields)
+	}
+	if !reflect.DeepEqual(required, object.Required) {
+		t.Errorf("%s: schema required %v don't match fields %v", name, object.Required, required)
+	}
+}
+
+func TestSchemasMatchTypes(t *testing.T) {
+	var pulse, detailed schemaObject
+	if err := json.Unmarshal(PulseResponse(), &pulse); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(DetailedPulseResponse(), &detailed); err != nil {
+		t.Fatal(err)
+	}
+
+	assertMatches(t, "PulseResponse", pulse, reflect.TypeOf(gopulse.PulseResponse{}))
+	assertMatches(t, "BuildInfo", pulse.Defs["BuildInfo"], reflect.TypeOf(gopulse.BuildInfo{}))
+	assertMatches(t, "DetailedPulseResponse", detailed, reflect.TypeOf(gopulse.DetailedPulseResponse{}))
+	assertMatches(t, "CheckDetail", detailed.Defs["CheckDetail"], reflect.TypeOf(gopulse.CheckDetail{}))
+
+	var scored schemaObject
+	if err := json.Unmarshal(ScoredResponse(), &scored); err != nil {
+		t.Fatal(err)
+	}
+	assertMatches(t, "ScoredResponse", scored, reflect.TypeOf(gopulse.ScoredResponse{}))
+
+	statuses := []string{
+		string(gopulse.StatusUp), string(gopulse.StatusDown), string(gopulse.StatusUnknown),
+		string(gopulse.StatusDegraded), string(gopulse.StatusInactive),
+	}
+	for name, object := range map[string]schemaObject{"PulseResponse": pulse, "DetailedPulseResponse": detailed} {
+		if !reflect.DeepEqual(object.Defs["Status"].Enum, statuses) {
+			t.Errorf("%s: status enum %v doesn't match %v", name, object.Defs["Status"].Enum, statuses)
+		}
+	}
+}
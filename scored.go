@@ -0,0 +1,57 @@
+package gopulse
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ScoredResponse is a machine-readable readiness variant for service meshes and load balancers
+// that route traffic in proportion to health, from a single probe call
+type ScoredResponse struct {
+	// Status is UP at full score, DOWN at zero and DEGRADED in between
+	Status Status `json:"status"`
+	// Score is the weighted share of ready checks from 0 to 1, see HealthAggregator.Score
+	Score float64 `json:"score"`
+	// Up counts the ready checks by priority name, e.g. {"critical": 2, "low": 1}
+	Up map[string]int `json:"up"`
+}
+
+// NewScoredResponse builds a ScoredResponse from a readiness score and the number of ready
+// checks per priority
+func NewScoredResponse(score float64, byPriority map[Priority]int) *ScoredResponse {
+	response := &ScoredResponse{
+		Status: StatusDegraded,
+		Score:  score,
+		Up:     make(map[string]int, len(byPriority)),
+	}
+	switch {
+	case score >= 1:
+		response.Status = StatusUp
+	case score <= 0:
+		response.Status = StatusDown
+	}
+	for priority, count := range byPriority {
+		response.Up[priority.String()] += count
+	}
+	return response
+}
+
+// ScoredResponse builds the ScoredResponse of the current readiness. In maintenance mode,
+// while draining or stabilizing the score is 0 so traffic is routed away.
+func (ha *HealthAggregator) ScoredResponse() *ScoredResponse {
+	score, up := ha.scoreByPriority()
+	if _, err := ha.readinessOverride(); err != nil {
+		score = 0
+	}
+	return NewScoredResponse(score, up)
+}
+
+// ScoredHandler serves ScoredResponse as JSON, with status 503 at zero score
+func (ha *HealthAggregator) ScoredHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := ha.ScoredResponse()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(ha.statusCode(response.Status))
+		_ = json.NewEncoder(w).Encode(response)
+	})
+}
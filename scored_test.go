@@ -0,0 +1,75 @@
+package gopulse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewScoredResponse(t *testing.T) {
+	for _, tc := range []struct {
+		score float64
+		want  Status
+	}{
+		{1, StatusUp},
+		{0.8, StatusDegraded},
+		{0, StatusDown},
+	} {
+		if got := NewScoredResponse(tc.score, nil).Status; got != tc.want {
+			t.Errorf("NewScoredResponse(%v) status = %s, want %s", tc.score, got, tc.want)
+		}
+	}
+
+	response := NewScoredResponse(0.5, map[Priority]int{PriorityCritical: 2, PriorityLow + 1: 1})
+	if want := map[string]int{"critical": 2, "priority 4": 1}; !maps.Equal(response.Up, want) {
+		t.Errorf("Expected up counts %v, got %v", want, response.Up)
+	}
+}
+
+func TestScoredHandler(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	critical := &mockHealthChecker{name: "critical"}
+	low := &mockHealthChecker{name: "low"}
+	cache := &mockHealthChecker{name: "cache"}
+	ha.RegisterHealthCheck(critical, PriorityCritical)
+	ha.RegisterHealthCheck(low, PriorityLow)
+	ha.RegisterHealthCheck(cache, PriorityLow)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(critical, nil, nil)
+	ha.UpdateHealth(low, nil, nil)
+	ha.UpdateHealth(cache, nil, errors.New("not ready"))
+	time.Sleep(100 * time.Millisecond)
+
+	serve := func() (int, ScoredResponse) {
+		recorder := httptest.NewRecorder()
+		ha.ScoredHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health/score", nil))
+		var response ScoredResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatal(err)
+		}
+		return recorder.Code, response
+	}
+
+	// Weights are 1 for critical and 1/4 for each low check
+	code, response := serve()
+	if code != http.StatusOK || response.Status != StatusDegraded || math.Abs(response.Score-1.25/1.5) > 1e-9 {
+		t.Errorf("Expected a degraded 200 with score 0.83, got %d %+v", code, response)
+	}
+	if want := map[string]int{"critical": 1, "low": 1}; !maps.Equal(response.Up, want) {
+		t.Errorf("Expected up counts %v, got %v", want, response.Up)
+	}
+
+	ha.SetMaintenance("upgrade")
+	if code, response := serve(); code != http.StatusServiceUnavailable || response.Score != 0 {
+		t.Errorf("Expected a zero score 503 during maintenance, got %d %+v", code, response)
+	}
+}
@@ -0,0 +1,174 @@
+package gopulse
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SharedState is the overall health one process publishes to a SharedStore
+type SharedState struct {
+	Live    bool      `json:"live"`
+	Ready   bool      `json:"ready"`
+	Updated time.Time `json:"updated"`
+}
+
+// SharedStore lets the aggregators of several processes, such as the workers of a prefork
+// server, share their health so any of them can serve a combined readiness
+type SharedStore interface {
+	// Publish stores the state of the process with the given id
+	Publish(id string, state SharedState) error
+	// Load returns the last published state of every process, by id
+	Load() (map[string]SharedState, error)
+}
+
+// FileStore is a SharedStore keeping one JSON file per process in a directory
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore returns a FileStore using dir, which must exist and be shared by the processes
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+// ErrInvalidSharedID is returned by FileStore.Publish for an id that can't name a file in
+// the store directory, such as one containing a path separator or ".."
+var ErrInvalidSharedID = errors.New("invalid shared id")
+
+// Publish atomically replaces the process's state file
+func (f *FileStore) Publish(id string, state SharedState) error {
+	if strings.ContainsAny(id, `/\`) || !filepath.IsLocal(id) {
+		return fmt.Errorf("%w: %q", ErrInvalidSharedID, id)
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(f.dir, ".gopulse-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(f.dir, id+".json"))
+}
+
+// Load reads every process's state file
+func (f *FileStore) Load() (map[string]SharedState, error) {
+	paths, err := filepath.Glob(filepath.Join(f.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	states := make(map[string]SharedState, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			// Removed since listing
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var state SharedState
+		if err := json.Unmarshal(data, &state); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		states[strings.TrimSuffix(filepath.Base(path), ".json")] = state
+	}
+	return states, nil
+}
+
+// sharedStaleFactor is how many publish intervals a process's state stays valid, after
+// which the process is assumed gone
+const sharedStaleFactor = 3
+
+// shareState publishes this process's health to the shared store every SharedInterval
+func (ha *HealthAggregator) shareState() {
+	ticker := time.NewTicker(ha.config.SharedInterval)
+	defer ticker.Stop()
+
+	for {
+		ha.publishShared()
+		select {
+		case <-ha.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// publishShared publishes the current overall health
+func (ha *HealthAggregator) publishShared() {
+	live, _ := ha.GetLiveness()
+	ready, _ := ha.GetReadiness()
+	state := SharedState{Live: live, Ready: ready, Updated: ha.config.Clock.Now()}
+	if err := ha.config.SharedStore.Publish(ha.sharedID(), state); err != nil {
+		ha.logger().Warn("publishing shared health failed", "error", err)
+	}
+}
+
+// sharedID returns this process's id in the shared store
+func (ha *HealthAggregator) sharedID() string {
+	if ha.config.SharedID != "" {
+		return ha.config.SharedID
+	}
+	return fmt.Sprint(os.Getpid())
+}
+
+// SharedReadiness combines this process's readiness with that of the other processes in the
+// shared store: ready only if every process is. Processes that haven't published for three
+// intervals are considered gone and ignored. Without a shared store it equals GetReadiness.
+func (ha *HealthAggregator) SharedReadiness() (bool, map[string]error) {
+	ready, errs := ha.GetReadiness()
+	if ha.config.SharedStore == nil {
+		return ready, errs
+	}
+
+	combined := make(map[string]error)
+	self := ha.sharedID()
+	if !ready {
+		combined[self] = fmt.Errorf("process %s not ready: %w", self, errors.Join(mapErrors(errs)...))
+	}
+
+	states, err := ha.config.SharedStore.Load()
+	if err != nil {
+		combined["shared-store"] = err
+		return false, combined
+	}
+	cutoff := ha.config.Clock.Now().Add(-sharedStaleFactor * ha.config.SharedInterval)
+	for id, state := range states {
+		if id == self || state.Updated.Before(cutoff) {
+			continue
+		}
+		if !state.Ready {
+			combined[id] = fmt.Errorf("process %s not ready", id)
+		}
+	}
+
+	if len(combined) > 0 {
+		return false, combined
+	}
+	return true, nil
+}
+
+// mapErrors returns the values of an error map
+func mapErrors(errs map[string]error) []error {
+	list := make([]error, 0, len(errs))
+	for _, err := range errs {
+		list = append(list, err)
+	}
+	return list
+}
@@ -0,0 +1,71 @@
+package gopulse
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSharedReadiness(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileStore(t.TempDir())
+	interval := 20 * time.Millisecond
+
+	newWorker := func(id string) (*HealthAggregator, *mockHealthChecker) {
+		ha := NewHealthAggregator(ctx, WithSharedStore(store, id, interval))
+		checker := &mockHealthChecker{name: "db"}
+		ha.RegisterHealthCheck(checker, PriorityCritical)
+		ha.Start()
+		return ha, checker
+	}
+	a, checkerA := newWorker("worker-a")
+	defer a.Stop()
+	b, checkerB := newWorker("worker-b")
+	defer b.Stop()
+
+	a.UpdateHealth(checkerA, nil, nil)
+	b.UpdateHealth(checkerB, nil, errors.New("connection refused"))
+	time.Sleep(100 * time.Millisecond)
+
+	ready, errs := a.SharedReadiness()
+	if ready || errs["worker-b"] == nil || errs["worker-a"] != nil {
+		t.Errorf("Expected worker-b to make the combined readiness fail, got %v %v", ready, errs)
+	}
+
+	b.UpdateHealth(checkerB, nil, nil)
+	time.Sleep(100 * time.Millisecond)
+	if ready, errs := a.SharedReadiness(); !ready {
+		t.Errorf("Expected combined readiness once all workers are ready, got %v", errs)
+	}
+
+	// A process that stopped publishing long ago is ignored
+	if err := store.Publish("worker-gone", SharedState{Updated: time.Now().Add(-time.Minute)}); err != nil {
+		t.Fatal(err)
+	}
+	if ready, errs := a.SharedReadiness(); !ready {
+		t.Errorf("Expected stale worker to be ignored, got %v", errs)
+	}
+}
+
+func TestFileStoreRejectsUnsafeIDs(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileStore(filepath.Join(dir, "store"))
+	if err := os.Mkdir(filepath.Join(dir, "store"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"", "..", "../escape", "a/b", `a\b`, "/tmp/abs"} {
+		if err := store.Publish(id, SharedState{Live: true}); !errors.Is(err, ErrInvalidSharedID) {
+			t.Errorf("Expected id %q to be rejected, got %v", id, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "escape.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected nothing written outside the store, got %v", err)
+	}
+	if err := store.Publish("worker-1", SharedState{Live: true}); err != nil {
+		t.Errorf("Expected a plain id to be accepted, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package gopulse
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// statsdClient emits check metrics to a statsd server over UDP. Packets are fire-and-forget,
+// so an unreachable server never slows down updates.
+type statsdClient struct {
+	conn   net.Conn
+	prefix string
+}
+
+// newStatsdClient connects a UDP socket to addr; metric names are prefixed with prefix
+func newStatsdClient(addr, prefix string) (*statsdClient, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	if prefix != "" && !strings.HasSuffix(prefix, ".") {
+		prefix += "."
+	}
+	return &statsdClient{conn: conn, prefix: prefix}, nil
+}
+
+// report sends liveness and readiness gauges and the check latency for a status update,
+// and counts the update as a transition when the check's state changed
+func (c *statsdClient) report(name string, prev, next *HealthStatus) {
+	metric := c.prefix + statsdName(name)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s.live:%d|g\n", metric, statsdBool(next.Liveness))
+	fmt.Fprintf(&b, "%s.ready:%d|g\n", metric, statsdBool(next.Readiness))
+	fmt.Fprintf(&b, "%s.duration:%d|ms", metric, next.Duration.Milliseconds())
+	if checkStatus(prev) != checkStatus(next) {
+		fmt.Fprintf(&b, "\n%s.transitions:1|c", metric)
+	}
+	_, _ = c.conn.Write([]byte(b.String()))
+}
+
+// close releases the socket
+func (c *statsdClient) close() {
+	_ = c.conn.Close()
+}
+
+// statsdName replaces characters that are special in the statsd line protocol
+func statsdName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ':', '|', '@', ' ', '\n':
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// statsdBool encodes a boolean as a gauge value
+func statsdBool(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
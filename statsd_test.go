@@ -0,0 +1,44 @@
+package gopulse
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatsd(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx, WithStatsd(conn.LocalAddr().String(), "svc.health"))
+	checker := &mockHealthChecker{name: "db:primary"}
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(checker, nil, errors.New("timeout"))
+
+	buf := make([]byte, 1024)
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"svc.health.db_primary.live:1|g",
+		"svc.health.db_primary.ready:0|g",
+		"svc.health.db_primary.duration:0|ms",
+		"svc.health.db_primary.transitions:1|c",
+	}
+	if got := strings.Split(string(buf[:n]), "\n"); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
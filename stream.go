@@ -0,0 +1,152 @@
+package gopulse
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// pulseResult is a probe result before it becomes a PulseResponse. The handlers stream it as
+// JSON straight from the failing checks, so large check sets don't need a Details map built
+// and marshaled on every probe.
+type pulseResult struct {
+	status Status
+	reason string
+	// checks listed in Details, all with the detail status
+	checks      []checkFailure
+	detail      Status
+	omitted     int
+	maintenance bool
+	build       *BuildInfo
+}
+
+// response materializes the result as a PulseResponse
+func (r *pulseResult) response() *PulseResponse {
+	response := &PulseResponse{
+		Status:      r.status,
+		Reason:      r.reason,
+		Omitted:     r.omitted,
+		Maintenance: r.maintenance,
+		Build:       r.build,
+	}
+	if len(r.checks) > 0 {
+		response.Details = make(map[string]Status, len(r.checks))
+		for _, check := range r.checks {
+			response.Details[check.name] = r.detail
+		}
+	}
+	return response
+}
+
+// encode writes the result as the JSON json.Encoder would write its PulseResponse, byte for
+// byte, including the trailing newline
+func (r *pulseResult) encode(w io.Writer) error {
+	buf := make([]byte, 0, 128+32*len(r.checks)+len(r.reason))
+	buf = append(buf, `{"status":`...)
+	buf = appendJSONString(buf, string(r.status))
+	if r.reason != "" {
+		buf = append(buf, `,"reason":`...)
+		buf = appendJSONString(buf, r.reason)
+	}
+	if len(r.checks) > 0 {
+		// Details keys are written sorted, as encoding/json sorts map keys. The checks are
+		// only listed in Details once the reason is built, so they are sorted in place.
+		slices.SortFunc(r.checks, func(a, b checkFailure) int {
+			return strings.Compare(a.name, b.name)
+		})
+		buf = append(buf, `,"details":{`...)
+		for i, check := range r.checks {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = appendJSONString(buf, check.name)
+			buf = append(buf, ':')
+			buf = appendJSONString(buf, string(r.detail))
+		}
+		buf = append(buf, '}')
+	}
+	if r.omitted != 0 {
+		buf = append(buf, `,"omitted":`...)
+		buf = strconv.AppendInt(buf, int64(r.omitted), 10)
+	}
+	if r.maintenance {
+		buf = append(buf, `,"maintenance":true`...)
+	}
+	if r.build != nil {
+		build, err := json.Marshal(r.build)
+		if err != nil {
+			return err
+		}
+		buf = append(buf, `,"build":`...)
+		buf = append(buf, build...)
+	}
+	buf = append(buf, "}\n"...)
+	_, err := w.Write(buf)
+	return err
+}
+
+// writeResult streams a probe result as JSON with a status code matching its status
+func (ha *HealthAggregator) writeResult(w http.ResponseWriter, result *pulseResult) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(ha.statusCode(result.status))
+	_ = result.encode(w)
+}
+
+// jsonHex holds the digits of \u escapes
+const jsonHex = "0123456789abcdef"
+
+// appendJSONString appends s as a JSON string escaped like encoding/json does by default,
+// HTML characters included. Invalid UTF-8 is replaced by U+FFFD.
+func appendJSONString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' && b != '<' && b != '>' && b != '&' {
+				i++
+				continue
+			}
+			dst = append(dst, s[start:i]...)
+			switch b {
+			case '\\', '"':
+				dst = append(dst, '\\', b)
+			case '\b':
+				dst = append(dst, '\\', 'b')
+			case '\f':
+				dst = append(dst, '\\', 'f')
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', jsonHex[b>>4], jsonHex[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		c, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case c == utf8.RuneError && size == 1:
+			dst = append(dst, s[start:i]...)
+			dst = utf8.AppendRune(dst, utf8.RuneError)
+		case c == '\u2028' || c == '\u2029':
+			// Valid JSON, but escaped for JSONP safety
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', jsonHex[c&0xF])
+		default:
+			i += size
+			continue
+		}
+		i += size
+		start = i
+	}
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
@@ -0,0 +1,152 @@
+package gopulse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAppendJSONString(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"db",
+		`quote " and \ backslash`,
+		"control \b\f\n\r\t\x00\x1f",
+		"<script>&</script>",
+		"unicode é 日本 \u2028 \u2029",
+	} {
+		want, err := json.Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := appendJSONString(nil, s); !bytes.Equal(got, want) {
+			t.Errorf("appendJSONString(%q) = %s, want %s", s, got, want)
+		}
+	}
+
+	// Invalid UTF-8 may be escaped or not depending on the Go version, but decodes the same
+	var decoded string
+	if err := json.Unmarshal(appendJSONString(nil, "invalid \xff utf-8 \xe6\x97"), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != "invalid \ufffd utf-8 \ufffd\ufffd" {
+		t.Errorf("Expected invalid UTF-8 replaced by U+FFFD, got %q", decoded)
+	}
+}
+
+func TestStreamedResponseMatchesJSON(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		result *pulseResult
+	}{
+		{"up", &pulseResult{status: StatusUp}},
+		{"down", &pulseResult{
+			status:  StatusDown,
+			reason:  "2 critical checks failing: z-db (timeout), <a&b> (\"refused\")",
+			checks:  []checkFailure{{name: "z-db"}, {name: "<a&b>"}},
+			detail:  StatusDown,
+			omitted: 3,
+		}},
+		{"degraded", &pulseResult{
+			status: StatusDegraded,
+			reason: "degraded: cache (evicting)",
+			checks: []checkFailure{{name: "cache"}},
+			detail: StatusDegraded,
+		}},
+		{"maintenance", &pulseResult{
+			status:      StatusDown,
+			reason:      "maintenance: upgrade",
+			maintenance: true,
+			build:       &BuildInfo{GoVersion: "go1.24", Version: "v1.0.0"},
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var want, got bytes.Buffer
+			if err := json.NewEncoder(&want).Encode(tc.result.response()); err != nil {
+				t.Fatal(err)
+			}
+			if err := tc.result.encode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != want.String() {
+				t.Errorf("Expected %s, got %s", want.String(), got.String())
+			}
+		})
+	}
+}
+
+func TestStreamedResponseCoversEveryField(t *testing.T) {
+	result := &pulseResult{
+		status:      StatusDown,
+		reason:      "maintenance: upgrade",
+		checks:      []checkFailure{{name: "db"}},
+		detail:      StatusDown,
+		omitted:     2,
+		maintenance: true,
+		build:       &BuildInfo{GoVersion: "go1.24", Path: "example.com/svc", Version: "v1.0.0", Revision: "abc"},
+	}
+	response := result.response()
+
+	// A PulseResponse field the result doesn't fill would be missing from streamed responses
+	fields := reflect.ValueOf(*response)
+	for i := range fields.NumField() {
+		if fields.Field(i).IsZero() {
+			t.Errorf("PulseResponse.%s isn't filled from pulseResult; stream it in encode", fields.Type().Field(i).Name)
+		}
+	}
+
+	want, err := json.Marshal(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got bytes.Buffer
+	if err := result.encode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != string(want)+"\n" {
+		t.Errorf("Expected %s, got %s", want, got.String())
+	}
+}
+
+// largeAggregator has n checks, every third one failing
+func largeAggregator(b *testing.B, n int) *HealthAggregator {
+	ha := NewHealthAggregator(context.Background())
+	checkers := make([]*mockHealthChecker, n)
+	for i := range checkers {
+		checkers[i] = &mockHealthChecker{name: fmt.Sprintf("check-%03d", i)}
+		ha.RegisterHealthCheck(checkers[i], Priority(i%4))
+	}
+	ha.Start()
+	b.Cleanup(ha.Stop)
+	for i, checker := range checkers {
+		var err error
+		if i%3 == 0 {
+			err = errors.New("connection refused")
+		}
+		ha.UpdateHealth(checker, nil, err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	return ha
+}
+
+func BenchmarkReadinessEncoding(b *testing.B) {
+	ha := largeAggregator(b, 500)
+	b.Run("marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = json.NewEncoder(io.Discard).Encode(ha.ReadinessResponse())
+		}
+	})
+	b.Run("stream", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = ha.readinessResult().encode(io.Discard)
+		}
+	})
+}
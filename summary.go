@@ -0,0 +1,210 @@
+package gopulse
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// checkFailure is a single failing check found while collecting all failures of a probe
+type checkFailure struct {
+	name     string
+	priority Priority
+	err      error
+}
+
+// describe formats the failure for a reason: the check's name, followed by its sanitized error
+// when withError is set. Reasons are served to clients, so errors are only included with
+// WithErrorDetails.
+func (f checkFailure) describe(withError bool) string {
+	switch {
+	case f.err == nil:
+		return f.name + " (no result yet)"
+	case !withError:
+		return f.name
+	default:
+		return fmt.Sprintf("%s (%s)", f.name, sanitizeError(f.err))
+	}
+}
+
+// failures collects every non-informational check failing the probe, ordered by priority then name
+func (ha *HealthAggregator) failures(probe probeFunc) []checkFailure {
+	ha.mu.RLock()
+	defer ha.mu.RUnlock()
+
+	var failures []checkFailure
+	now := ha.config.Clock.Monotonic()
+	for name, status := range ha.statuses {
+		if !status.affectsHealth() {
+			continue
+		}
+		if ok, err := probe(name, status, now); !ok {
+			failures = append(failures, checkFailure{name: name, priority: status.Priority, err: err})
+		}
+	}
+
+	sort.Slice(failures, func(i, j int) bool {
+		if failures[i].priority != failures[j].priority {
+			return failures[i].priority < failures[j].priority
+		}
+		return failures[i].name < failures[j].name
+	})
+	return failures
+}
+
+// CheckError describes why a check fails; a nil field means that probe passes
+type CheckError struct {
+	Liveness  error
+	Readiness error
+}
+
+// GetCombinedErrors returns every failing check with its liveness and readiness errors in
+// one map, for rendering a single table of problems
+func (ha *HealthAggregator) GetCombinedErrors() map[string]CheckError {
+	combined := make(map[string]CheckError)
+	for _, failure := range ha.failures(ha.statusLiveness) {
+		combined[failure.name] = CheckError{Liveness: failure.err}
+	}
+	for _, failure := range ha.failures(ha.statusReadiness) {
+		entry := combined[failure.name]
+		entry.Readiness = failure.err
+		combined[failure.name] = entry
+	}
+	return combined
+}
+
+// Summary returns a one-line, human-readable reason why the service is not ready, such as
+// "2 critical checks failing: payments-db (connection refused), cache (timeout)".
+// Unlike the reason in responses it always includes the errors. It returns an empty string
+// when the service is ready.
+func (ha *HealthAggregator) Summary() string {
+	if _, err := ha.readinessOverride(); err != nil {
+		return err.Error()
+	}
+	failures, omitted := ha.limitFailures(ha.failures(ha.statusReadiness))
+	return summarize(failures, omitted, true)
+}
+
+// ReadinessError returns nil when the service is ready, and otherwise an errors.Join of every
+// failing check's error prefixed with its name, highest priority first, so errors.Is and
+// errors.As still match individual check errors:
+//
+//	if err := ha.ReadinessError(); err != nil {
+//		return fmt.Errorf("not ready: %w", err)
+//	}
+func (ha *HealthAggregator) ReadinessError() error {
+	if _, err := ha.readinessOverride(); err != nil {
+		return err
+	}
+	var errs []error
+	for _, failure := range ha.failures(ha.statusReadiness) {
+		err := failure.err
+		if err == nil {
+			err = errNoResult
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", failure.name, err))
+	}
+	return errors.Join(errs...)
+}
+
+// OverallStatus summarizes liveness and readiness as one value for a single indicator: DOWN
+// when not live or not ready (including maintenance), DEGRADED when ready but some checks are
+// degraded, e.g. failing soft dependencies, and UP otherwise
+func (ha *HealthAggregator) OverallStatus() Status {
+	if live, _ := ha.GetLiveness(); !live {
+		return StatusDown
+	}
+	if ready, _ := ha.GetReadiness(); !ready {
+		return StatusDown
+	}
+	if len(ha.degradedChecks()) > 0 {
+		return StatusDegraded
+	}
+	return StatusUp
+}
+
+// errNoResult stands for the missing error of a check that hasn't reported yet
+var errNoResult = errors.New("no result yet")
+
+// limitFailures keeps at most MaxReportedErrors failures, highest priority first, and
+// returns how many were omitted
+func (ha *HealthAggregator) limitFailures(failures []checkFailure) ([]checkFailure, int) {
+	limit := ha.config.MaxReportedErrors
+	if limit <= 0 || len(failures) <= limit {
+		return failures, 0
+	}
+	return failures[:limit], len(failures) - limit
+}
+
+// summarize formats failures grouped by priority, most important first, with their errors
+// when withErrors is set
+func summarize(failures []checkFailure, omitted int, withErrors bool) string {
+	var groups []string
+	for i := 0; i < len(failures); {
+		j := i
+		var checks []string
+		for ; j < len(failures) && failures[j].priority == failures[i].priority; j++ {
+			checks = append(checks, failures[j].describe(withErrors))
+		}
+
+		noun := "check"
+		if len(checks) > 1 {
+			noun = "checks"
+		}
+		groups = append(groups, fmt.Sprintf("%d %s %s failing: %s",
+			len(checks), failures[i].priority, noun, strings.Join(checks, ", ")))
+		i = j
+	}
+	if omitted > 0 {
+		groups = append(groups, fmt.Sprintf("%d more omitted", omitted))
+	}
+	return strings.Join(groups, "; ")
+}
+
+// LivenessResponse builds the PulseResponse for a liveness probe, listing every failing check
+func (ha *HealthAggregator) LivenessResponse() *PulseResponse {
+	return ha.livenessResult().response()
+}
+
+// ReadinessResponse builds the PulseResponse for a readiness probe, listing every failing check
+func (ha *HealthAggregator) ReadinessResponse() *PulseResponse {
+	return ha.readinessResult().response()
+}
+
+// livenessResult evaluates the liveness probe
+func (ha *HealthAggregator) livenessResult() *pulseResult {
+	return ha.newResult(ha.failures(ha.statusLiveness))
+}
+
+// readinessResult evaluates the readiness probe, reporting degraded checks when otherwise up
+func (ha *HealthAggregator) readinessResult() *pulseResult {
+	if name, err := ha.readinessOverride(); err != nil {
+		return ha.overrideResult(name, err)
+	}
+	result := ha.newResult(ha.failures(ha.statusReadiness))
+	if result.status == StatusUp {
+		if degraded := ha.degradedChecks(); len(degraded) > 0 {
+			result.degrade(degraded, ha.config.ErrorDetails)
+		}
+	}
+	return result
+}
+
+// newResult builds a probe result from the failures of a probe, with a summary reason when
+// down. At most MaxReportedErrors failures are included; the rest are only counted.
+func (ha *HealthAggregator) newResult(failures []checkFailure) *pulseResult {
+	if len(failures) == 0 {
+		return &pulseResult{status: StatusUp, build: ha.buildInfo}
+	}
+
+	failures, omitted := ha.limitFailures(failures)
+	return &pulseResult{
+		status:  StatusDown,
+		reason:  summarize(failures, omitted, ha.config.ErrorDetails),
+		checks:  failures,
+		detail:  StatusDown,
+		omitted: omitted,
+		build:   ha.buildInfo,
+	}
+}
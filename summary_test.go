@@ -0,0 +1,284 @@
+package gopulse
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestSummary(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	db := &mockHealthChecker{name: "payments-db"}
+	cache := &mockHealthChecker{name: "cache"}
+	search := &mockHealthChecker{name: "search"}
+	healthy := &mockHealthChecker{name: "healthy"}
+
+	ha.RegisterHealthCheck(db, PriorityCritical)
+	ha.RegisterHealthCheck(cache, PriorityCritical)
+	ha.RegisterHealthCheck(search, PriorityLow)
+	ha.RegisterHealthCheck(healthy, PriorityHigh)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(healthy, nil, nil)
+	ha.UpdateHealth(db, nil, nil)
+	ha.UpdateHealth(cache, nil, nil)
+	ha.UpdateHealth(search, nil, nil)
+	time.Sleep(100 * time.Millisecond)
+
+	if summary := ha.Summary(); summary != "" {
+		t.Errorf("Expected empty summary when ready, got %q", summary)
+	}
+
+	ha.UpdateHealth(db, nil, errors.New("connection refused"))
+	ha.UpdateHealth(cache, nil, errors.New("timeout"))
+	ha.UpdateHealth(search, nil, errors.New("index missing"))
+	time.Sleep(100 * time.Millisecond)
+
+	expected := "2 critical checks failing: cache (timeout), payments-db (connection refused); " +
+		"1 low check failing: search (index missing)"
+	if summary := ha.Summary(); summary != expected {
+		t.Errorf("Expected summary %q, got %q", expected, summary)
+	}
+}
+
+func TestReadinessResponse(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	db := &mockHealthChecker{name: "db"}
+	cache := &mockHealthChecker{name: "cache"}
+
+	ha.RegisterHealthCheck(db, PriorityCritical)
+	ha.RegisterHealthCheck(cache, PriorityHigh)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(db, nil, nil)
+	ha.UpdateHealth(cache, nil, nil)
+	time.Sleep(100 * time.Millisecond)
+
+	response := ha.ReadinessResponse()
+	if response.Status != StatusUp || response.Reason != "" {
+		t.Errorf("Expected UP without reason, got %+v", response)
+	}
+
+	ha.UpdateHealth(db, nil, errors.New("connection refused"))
+	ha.UpdateHealth(cache, nil, errors.New("timeout"))
+	time.Sleep(100 * time.Millisecond)
+
+	response = ha.ReadinessResponse()
+	if response.Status != StatusDown {
+		t.Errorf("Expected DOWN, got %s", response.Status)
+	}
+	if len(response.Details) != 2 {
+		t.Errorf("Expected every failing check in details, got %v", response.Details)
+	}
+	if response.Reason == "" {
+		t.Error("Expected a reason when down")
+	}
+
+	if response := ha.LivenessResponse(); response.Status != StatusUp {
+		t.Errorf("Expected liveness to be UP, got %s", response.Status)
+	}
+}
+
+func TestReasonErrorDetails(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		enabled bool
+		reason  string
+	}{
+		{false, "1 critical check failing: db"},
+		{true, "1 critical check failing: db (connection refused to 10.0.0.5:5432 )"},
+	} {
+		ha := NewHealthAggregator(ctx, WithErrorDetails(tc.enabled))
+		db := &mockHealthChecker{name: "db"}
+		ha.RegisterHealthCheck(db, PriorityCritical)
+		ha.Start()
+		ha.UpdateHealth(db, nil, errors.New("connection refused to 10.0.0.5:5432\n"))
+		time.Sleep(100 * time.Millisecond)
+
+		// The reason is served to clients, so errors are only included, sanitized, on request
+		if reason := ha.ReadinessResponse().Reason; reason != tc.reason {
+			t.Errorf("With error details %v, expected reason %q, got %q", tc.enabled, tc.reason, reason)
+		}
+		ha.Stop()
+	}
+}
+
+func TestMaxReportedErrors(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx, WithMaxReportedErrors(2))
+	checkers := []*mockHealthChecker{
+		{name: "low", priority: PriorityLow},
+		{name: "critical", priority: PriorityCritical},
+		{name: "medium", priority: PriorityMedium},
+		{name: "high", priority: PriorityHigh},
+	}
+	for _, checker := range checkers {
+		ha.RegisterHealthCheck(checker, checker.priority)
+	}
+	ha.Start()
+	defer ha.Stop()
+
+	for _, checker := range checkers {
+		ha.UpdateHealth(checker, nil, errors.New("down"))
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	response := ha.ReadinessResponse()
+	if len(response.Details) != 2 || response.Omitted != 2 {
+		t.Fatalf("Expected 2 details and 2 omitted, got %v and %d", response.Details, response.Omitted)
+	}
+	if _, ok := response.Details["critical"]; !ok {
+		t.Error("Expected the highest priority failures to be kept")
+	}
+	if _, ok := response.Details["high"]; !ok {
+		t.Error("Expected the highest priority failures to be kept")
+	}
+
+	expected := "1 critical check failing: critical (down); 1 high check failing: high (down); 2 more omitted"
+	if summary := ha.Summary(); summary != expected {
+		t.Errorf("Expected summary %q, got %q", expected, summary)
+	}
+}
+
+func TestBuildInfo(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx, WithBuildInfo(true))
+
+	response := ha.ReadinessResponse()
+	if response.Build == nil || response.Build.GoVersion != runtime.Version() {
+		t.Errorf("Expected build info with Go version %s, got %+v", runtime.Version(), response.Build)
+	}
+
+	if response := NewHealthAggregator(ctx).ReadinessResponse(); response.Build != nil {
+		t.Error("Expected no build info by default")
+	}
+}
+
+func TestGetCombinedErrors(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	both := &mockHealthChecker{name: "both"}
+	readiness := &mockHealthChecker{name: "readiness"}
+	healthy := &mockHealthChecker{name: "healthy"}
+
+	ha.RegisterHealthCheck(both, PriorityCritical)
+	ha.RegisterHealthCheck(readiness, PriorityHigh)
+	ha.RegisterHealthCheck(healthy, PriorityLow)
+	ha.Start()
+	defer ha.Stop()
+
+	deadlock := errors.New("deadlock")
+	timeout := errors.New("timeout")
+	ha.UpdateHealth(both, deadlock, timeout)
+	ha.UpdateHealth(readiness, nil, timeout)
+	ha.UpdateHealth(healthy, nil, nil)
+	time.Sleep(100 * time.Millisecond)
+
+	combined := ha.GetCombinedErrors()
+	if len(combined) != 2 {
+		t.Fatalf("Expected 2 failing checks, got %v", combined)
+	}
+	if got := combined["both"]; got.Liveness != deadlock || got.Readiness != timeout {
+		t.Errorf("Expected both probes to fail, got %+v", got)
+	}
+	if got := combined["readiness"]; got.Liveness != nil || got.Readiness != timeout {
+		t.Errorf("Expected only readiness to fail, got %+v", got)
+	}
+}
+
+func TestReadinessError(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	db := &mockHealthChecker{name: "db"}
+	cache := &mockHealthChecker{name: "cache"}
+	ha.RegisterHealthCheck(db, PriorityCritical)
+	ha.RegisterHealthCheck(cache, PriorityLow)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(db, nil, nil)
+	ha.UpdateHealth(cache, nil, nil)
+	time.Sleep(100 * time.Millisecond)
+	if err := ha.ReadinessError(); err != nil {
+		t.Errorf("Expected nil when ready, got %v", err)
+	}
+
+	refused := errors.New("connection refused")
+	ha.UpdateHealth(db, nil, refused)
+	ha.UpdateHealth(cache, nil, context.DeadlineExceeded)
+	time.Sleep(100 * time.Millisecond)
+
+	err := ha.ReadinessError()
+	if !errors.Is(err, refused) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected every failing check's error to match, got %v", err)
+	}
+	if want := "db: connection refused\ncache: context deadline exceeded"; err.Error() != want {
+		t.Errorf("Expected %q, got %q", want, err.Error())
+	}
+
+	ha.SetMaintenance("upgrade")
+	if err := ha.ReadinessError(); !errors.Is(err, ErrMaintenance) {
+		t.Errorf("Expected the maintenance error, got %v", err)
+	}
+}
+
+func TestOverallStatus(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	db := &mockHealthChecker{name: "db"}
+	search := &mockHealthChecker{name: "search"}
+	ha.RegisterHealthCheck(db, PriorityCritical)
+	ha.RegisterHealthCheckWithOptions(search, PriorityLow, CheckOptions{Soft: true})
+	ha.Start()
+	defer ha.Stop()
+
+	if status := ha.OverallStatus(); status != StatusDown {
+		t.Errorf("Expected DOWN before any result, got %s", status)
+	}
+
+	for _, tc := range []struct {
+		name                string
+		dbLiveness, dbReady error
+		searchReady         error
+		want                Status
+	}{
+		{"healthy", nil, nil, nil, StatusUp},
+		{"soft dependency down", nil, nil, errors.New("timeout"), StatusDegraded},
+		{"critical not ready", nil, errors.New("refused"), nil, StatusDown},
+		{"not live", errors.New("deadlock"), nil, nil, StatusDown},
+	} {
+		ha.UpdateHealth(db, tc.dbLiveness, tc.dbReady)
+		ha.UpdateHealth(search, nil, tc.searchReady)
+		time.Sleep(50 * time.Millisecond)
+		if status := ha.OverallStatus(); status != tc.want {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.want, status)
+		}
+	}
+
+	ha.UpdateHealth(db, nil, nil)
+	time.Sleep(50 * time.Millisecond)
+	ha.SetMaintenance("upgrade")
+	if status := ha.OverallStatus(); status != StatusDown {
+		t.Errorf("Expected DOWN during maintenance, got %s", status)
+	}
+}
+
+func TestPriorityString(t *testing.T) {
+	for priority, want := range map[Priority]string{
+		PriorityCritical: "critical",
+		PriorityHigh:     "high",
+		PriorityMedium:   "medium",
+		PriorityLow:      "low",
+		PriorityLow + 2:  "priority 5",
+	} {
+		if got := priority.String(); got != want {
+			t.Errorf("Expected %q for %d, got %q", want, int(priority), got)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package gopulse
+
+import (
+	"slices"
+	"sort"
+)
+
+// GetReadinessByTag returns the readiness of the checks registered with the given tag, e.g.
+// "readiness of all external dependencies", in priority order like GetReadiness. Without
+// such checks it reports ready.
+func (ha *HealthAggregator) GetReadinessByTag(tag string) (bool, map[string]error) {
+	if name, err := ha.readinessOverride(); err != nil {
+		return false, map[string]error{name: err}
+	}
+	return ha.aggregate(ha.statusReadiness, func(status *HealthStatus) bool {
+		return slices.Contains(status.Tags, tag)
+	})
+}
+
+// ListByTag returns the names of the checks registered with the given tag, sorted
+func (ha *HealthAggregator) ListByTag(tag string) []string {
+	ha.mu.RLock()
+	defer ha.mu.RUnlock()
+
+	var names []string
+	for name, status := range ha.statuses {
+		if slices.Contains(status.Tags, tag) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
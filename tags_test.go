@@ -0,0 +1,57 @@
+package gopulse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTags(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	db := &mockHealthChecker{name: "db"}
+	payments := &mockHealthChecker{name: "payments"}
+	cache := &mockHealthChecker{name: "cache"}
+	ha.RegisterHealthCheckWithOptions(db, PriorityCritical, CheckOptions{Tags: []string{"stateful"}})
+	ha.RegisterHealthCheckWithOptions(payments, PriorityHigh, CheckOptions{Tags: []string{"external", "region:us-east"}})
+	ha.RegisterHealthCheckWithOptions(cache, PriorityLow, CheckOptions{Tags: []string{"external", "stateful"}})
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(db, nil, errors.New("down"))
+	ha.UpdateHealth(payments, nil, nil)
+	ha.UpdateHealth(cache, nil, errors.New("evicted"))
+	time.Sleep(50 * time.Millisecond)
+
+	if names := ha.ListByTag("external"); !reflect.DeepEqual(names, []string{"cache", "payments"}) {
+		t.Errorf("Expected external checks, got %v", names)
+	}
+	if names := ha.ListByTag("unknown"); len(names) != 0 {
+		t.Errorf("Expected no checks for an unknown tag, got %v", names)
+	}
+
+	ready, errs := ha.GetReadinessByTag("external")
+	if ready || len(errs) != 1 || errs["cache"] == nil {
+		t.Errorf("Expected only the failing external check, got %v", errs)
+	}
+	if ready, errs := ha.GetReadinessByTag("region:us-east"); !ready {
+		t.Errorf("Expected the region to be ready, got %v", errs)
+	}
+
+	for _, check := range ha.DetailedReadinessResponse().Checks {
+		if check.Name == "payments" && !reflect.DeepEqual(check.Tags, []string{"external", "region:us-east"}) {
+			t.Errorf("Expected tags in the detailed response, got %v", check.Tags)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	ha.MetricsHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if want := `{check="payments",tags="external,region:us-east"}`; !strings.Contains(recorder.Body.String(), want) {
+		t.Errorf("Expected metrics labels %s, got:\n%s", want, recorder.Body.String())
+	}
+}
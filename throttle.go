@@ -0,0 +1,73 @@
+package gopulse
+
+import "time"
+
+// callbackThrottle holds back OnStatusChange calls of checkers that changed too recently,
+// owned by processUpdates
+type callbackThrottle struct {
+	timer *time.Timer
+	// Monotonic time of each checker's last call
+	last map[string]time.Duration
+	// Latest status held back per checker
+	pending map[string]*HealthStatus
+}
+
+// notifyStatusChange calls OnStatusChange, at most once per ChangeCallbackThrottle for each
+// checker: a status arriving within the window is held back, replacing any held before, and
+// delivered once the window has passed
+func (ha *HealthAggregator) notifyStatusChange(name string, status *HealthStatus) {
+	window := ha.config.ChangeCallbackThrottle
+	if window <= 0 {
+		ha.callStatusChange(name, status)
+		return
+	}
+
+	now := ha.config.Clock.Monotonic()
+	if last, ok := ha.throttle.last[name]; !ok || now-last >= window {
+		delete(ha.throttle.pending, name)
+		ha.throttle.last[name] = now
+		ha.callStatusChange(name, status)
+		return
+	}
+	ha.throttle.pending[name] = status
+	ha.scheduleThrottled(now)
+}
+
+// flushThrottled delivers the held back statuses whose window has passed
+func (ha *HealthAggregator) flushThrottled() {
+	window := ha.config.ChangeCallbackThrottle
+	now := ha.config.Clock.Monotonic()
+	for name, status := range ha.throttle.pending {
+		if now-ha.throttle.last[name] < window {
+			continue
+		}
+		delete(ha.throttle.pending, name)
+		ha.mu.RLock()
+		_, registered := ha.checkers[name]
+		ha.mu.RUnlock()
+		if !registered {
+			delete(ha.throttle.last, name)
+			continue
+		}
+		ha.throttle.last[name] = now
+		ha.callStatusChange(name, status)
+	}
+	ha.scheduleThrottled(now)
+}
+
+// scheduleThrottled sets the throttle timer for the earliest held back status
+func (ha *HealthAggregator) scheduleThrottled(now time.Duration) {
+	if len(ha.throttle.pending) == 0 {
+		return
+	}
+	wait := ha.config.ChangeCallbackThrottle
+	for name := range ha.throttle.pending {
+		wait = min(wait, ha.throttle.last[name]+ha.config.ChangeCallbackThrottle-now)
+	}
+	ha.throttle.timer.Reset(max(wait, 0))
+}
+
+// callStatusChange runs the OnStatusChange callback
+func (ha *HealthAggregator) callStatusChange(name string, status *HealthStatus) {
+	ha.runCallback("OnStatusChange", func() { ha.config.OnStatusChange(name, status) })
+}
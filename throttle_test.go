@@ -0,0 +1,56 @@
+package gopulse
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestChangeCallbackThrottle(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	var calls []*HealthStatus
+	ha := NewHealthAggregator(ctx,
+		WithChangeCallbackThrottle(200*time.Millisecond),
+		WithStatusChangeCallback(func(name string, status *HealthStatus) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, status)
+		}),
+	)
+	checker := &mockHealthChecker{name: "flappy"}
+	ha.RegisterHealthCheck(checker, PriorityCritical)
+	ha.Start()
+	defer ha.Stop()
+
+	callCount := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(calls)
+	}
+
+	// The first change is delivered at once, the flaps within the window are collapsed
+	for i := 0; i < 6; i++ {
+		var err error
+		if i%2 == 1 {
+			err = errors.New("flap")
+		}
+		ha.UpdateHealth(checker, nil, err)
+		time.Sleep(20 * time.Millisecond)
+	}
+	if n := callCount(); n != 1 {
+		t.Fatalf("Expected 1 call within the window, got %d", n)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if len(calls) != 2 {
+		t.Fatalf("Expected the latest state after the window, got %d calls", len(calls))
+	}
+	if !calls[0].Readiness || calls[1].Readiness {
+		t.Errorf("Expected the first and the latest state, got %v then %v", calls[0].Readiness, calls[1].Readiness)
+	}
+}
@@ -0,0 +1,64 @@
+package gopulse
+
+import (
+	"context"
+	"fmt"
+	"maps"
+)
+
+// Validate runs every registered checker once, in parallel, and returns the errors of those
+// that fail, e.g. at startup to fail fast on a wrong URL or bad credentials before serving
+// traffic. Results aren't stored and don't go through the result interceptor, so validation
+// doesn't affect health state, but the BeforeCheck and AfterCheck hooks are called. The
+// aggregator doesn't need to be started. A degraded readiness error isn't a failure. Checkers
+// still running when ctx is done report ErrCheckCanceled.
+func (ha *HealthAggregator) Validate(ctx context.Context) map[string]error {
+	ha.mu.RLock()
+	checkers := maps.Clone(ha.checkers)
+	ha.mu.RUnlock()
+
+	type result struct {
+		name string
+		err  error
+	}
+	results := make(chan result, len(checkers))
+	for name, checker := range checkers {
+		go func() {
+			livenessErr, readinessErr, _ := ha.execute(ctx, name, checker)
+			results <- result{name: name, err: validationError(livenessErr, readinessErr)}
+		}()
+	}
+
+	errs := make(map[string]error)
+	for pending := len(checkers); pending > 0; pending-- {
+		select {
+		case r := <-results:
+			if r.err != nil {
+				errs[r.name] = r.err
+			}
+			delete(checkers, r.name)
+		case <-ctx.Done():
+			for name := range checkers {
+				errs[name] = fmt.Errorf("%w: %w", ErrCheckCanceled, ctx.Err())
+			}
+			return errs
+		}
+	}
+	return errs
+}
+
+// validationError combines the probe errors of a validated checker, nil when it passes
+func validationError(livenessErr, readinessErr error) error {
+	if isDegraded(readinessErr) {
+		readinessErr = nil
+	}
+	switch {
+	case livenessErr != nil && readinessErr != nil:
+		return fmt.Errorf("liveness: %w; readiness: %w", livenessErr, readinessErr)
+	case livenessErr != nil:
+		return fmt.Errorf("liveness: %w", livenessErr)
+	case readinessErr != nil:
+		return fmt.Errorf("readiness: %w", readinessErr)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package gopulse
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	good := &mockHealthChecker{name: "good"}
+	degraded := &mockHealthChecker{name: "degraded", readinessErr: Degraded(errors.New("lagging"))}
+	badURL := &mockHealthChecker{name: "bad-url", readinessErr: errors.New("no such host")}
+	slow := &slowHealthChecker{mockHealthChecker: mockHealthChecker{name: "slow"}, delay: 200 * time.Millisecond}
+	for _, checker := range []HealthChecker{good, degraded, badURL, slow} {
+		ha.RegisterHealthCheck(checker, PriorityCritical)
+	}
+
+	validateCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	errs := ha.Validate(validateCtx)
+	if len(errs) != 2 {
+		t.Errorf("Expected only bad-url and slow to fail, got %v", errs)
+	}
+	if !errors.Is(errs["bad-url"], badURL.readinessErr) || errs["bad-url"].Error() != "readiness: no such host" {
+		t.Errorf("Expected the readiness error of bad-url, got %v", errs["bad-url"])
+	}
+	if !errors.Is(errs["slow"], ErrCheckCanceled) {
+		t.Errorf("Expected slow to be canceled, got %v", errs["slow"])
+	}
+
+	// Validation doesn't store results
+	if status := ha.Snapshot()["bad-url"]; status.checked {
+		t.Errorf("Expected validation not to affect state, got %+v", status)
+	}
+}
+
+func TestValidateCallsHooks(t *testing.T) {
+	var calls syncBuffer
+	ha := NewHealthAggregator(context.Background(),
+		WithBeforeCheck(func(name string) {
+			calls.Write([]byte("before " + name + "\n"))
+		}),
+		WithAfterCheck(func(name string, liveness, readiness error, d time.Duration) {
+			calls.Write([]byte("after " + name + "\n"))
+		}),
+	)
+	ha.RegisterHealthCheck(&mockHealthChecker{name: "db"}, PriorityCritical)
+
+	ha.Validate(context.Background())
+	if got := calls.String(); got != "before db\nafter db\n" {
+		t.Errorf("Expected validation to call the hooks, got %q", got)
+	}
+}
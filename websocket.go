@@ -0,0 +1,142 @@
+package gopulse
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// WebSocketConn is the part of a WebSocket connection WebSocketHandler needs. It is
+// implemented by *websocket.Conn of gorilla/websocket, so no library is imposed.
+type WebSocketConn interface {
+	WriteJSON(v any) error
+	// ReadMessage blocks until a message arrives, handling control frames such as ping and
+	// close; it fails once the client closed or dropped the connection
+	ReadMessage() (messageType int, p []byte, err error)
+	Close() error
+}
+
+// WebSocketUpgrader upgrades a request to a WebSocket connection, e.g. by wrapping
+// gorilla/websocket's Upgrader.Upgrade
+type WebSocketUpgrader func(w http.ResponseWriter, r *http.Request) (WebSocketConn, error)
+
+// HealthEvent is a message streamed by WebSocketHandler
+type HealthEvent struct {
+	// Type is "snapshot" for the full state or "update" for a single check's new result
+	Type string `json:"type"`
+	// Status is the overall readiness after the event
+	Status Status `json:"status"`
+	// Checks lists every check for a snapshot, or the updated check for an update
+	Checks []CheckDetail `json:"checks"`
+}
+
+// subscriberBuffer is how many updates a subscriber may lag behind before it is resynced
+const subscriberBuffer = 64
+
+// subscriber receives the names of updated checks
+type subscriber struct {
+	updates chan string
+	// overflowed is set when updates were dropped, so the next event must be a snapshot
+	overflowed atomic.Bool
+}
+
+// subscribe registers a subscriber notified of every applied update until unsubscribed
+func (ha *HealthAggregator) subscribe() *subscriber {
+	sub := &subscriber{updates: make(chan string, subscriberBuffer)}
+	ha.subsMu.Lock()
+	defer ha.subsMu.Unlock()
+	ha.subscribers[sub] = struct{}{}
+	return sub
+}
+
+// unsubscribe stops notifying a subscriber
+func (ha *HealthAggregator) unsubscribe(sub *subscriber) {
+	ha.subsMu.Lock()
+	defer ha.subsMu.Unlock()
+	delete(ha.subscribers, sub)
+}
+
+// notifySubscribers passes an updated check to every subscriber without blocking
+func (ha *HealthAggregator) notifySubscribers(name string) {
+	ha.subsMu.Lock()
+	defer ha.subsMu.Unlock()
+	for sub := range ha.subscribers {
+		select {
+		case sub.updates <- name:
+		default:
+			sub.overflowed.Store(true)
+		}
+	}
+}
+
+// WebSocketHandler streams health over a WebSocket: a readiness snapshot of every check on
+// connect, then an update event for every applied check result. A client that falls behind
+// gets a fresh snapshot instead of the missed updates. Messages from the client are read and
+// discarded, so its close and ping frames are handled. The stream ends when the client closes
+// the connection, a read or write fails, the request context is done or the aggregator stops.
+func (ha *HealthAggregator) WebSocketHandler(upgrade WebSocketUpgrader) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrade(w, r)
+		if err != nil {
+			// The upgrader has already replied to the client
+			return
+		}
+		defer conn.Close()
+
+		sub := ha.subscribe()
+		defer ha.unsubscribe(sub)
+
+		// After the hijack the request context isn't canceled on disconnect: reading is how a
+		// closed or dead connection is noticed
+		disconnected := make(chan struct{})
+		go func() {
+			defer close(disconnected)
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+
+		if conn.WriteJSON(ha.snapshotEvent()) != nil {
+			return
+		}
+		for {
+			select {
+			case name := <-sub.updates:
+				event := ha.updateEvent(name)
+				if sub.overflowed.Swap(false) {
+					event = ha.snapshotEvent()
+				}
+				if event == nil {
+					continue
+				}
+				if conn.WriteJSON(event) != nil {
+					return
+				}
+			case <-disconnected:
+				return
+			case <-r.Context().Done():
+				return
+			case <-ha.ctx.Done():
+				return
+			}
+		}
+	})
+}
+
+// snapshotEvent returns the readiness of every check
+func (ha *HealthAggregator) snapshotEvent() *HealthEvent {
+	response := ha.DetailedReadinessResponse()
+	return &HealthEvent{Type: "snapshot", Status: response.Status, Checks: response.Checks}
+}
+
+// updateEvent returns the readiness of a single check, or nil if it is no longer registered
+func (ha *HealthAggregator) updateEvent(name string) *HealthEvent {
+	response := ha.DetailedReadinessResponse()
+	for _, check := range response.Checks {
+		if check.Name == name {
+			return &HealthEvent{Type: "update", Status: response.Status, Checks: []CheckDetail{check}}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package gopulse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fakeWebSocket records the events written to it, its reads failing with the errors sent to
+// reads or once closed
+type fakeWebSocket struct {
+	events chan *HealthEvent
+	reads  chan error
+	closed chan struct{}
+}
+
+func newFakeWebSocket() *fakeWebSocket {
+	return &fakeWebSocket{
+		events: make(chan *HealthEvent, 10),
+		reads:  make(chan error),
+		closed: make(chan struct{}),
+	}
+}
+
+func (c *fakeWebSocket) ReadMessage() (int, []byte, error) {
+	select {
+	case err := <-c.reads:
+		return 0, nil, err
+	case <-c.closed:
+		return 0, nil, errors.New("use of closed connection")
+	}
+}
+
+func (c *fakeWebSocket) WriteJSON(v any) error {
+	c.events <- v.(*HealthEvent)
+	return nil
+}
+
+func (c *fakeWebSocket) Close() error {
+	close(c.closed)
+	return nil
+}
+
+func (c *fakeWebSocket) next(t *testing.T) *HealthEvent {
+	t.Helper()
+	select {
+	case event := <-c.events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("Expected an event")
+		return nil
+	}
+}
+
+func TestWebSocketHandler(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	db := &mockHealthChecker{name: "db"}
+	cache := &mockHealthChecker{name: "cache"}
+	ha.RegisterHealthCheck(db, PriorityCritical)
+	ha.RegisterHealthCheck(cache, PriorityLow)
+	ha.Start()
+	defer ha.Stop()
+
+	ha.UpdateHealth(db, nil, nil)
+	ha.UpdateHealth(cache, nil, nil)
+	time.Sleep(50 * time.Millisecond)
+
+	conn := newFakeWebSocket()
+	upgrade := func(w http.ResponseWriter, r *http.Request) (WebSocketConn, error) {
+		return conn, nil
+	}
+	reqCtx, cancel := context.WithCancel(ctx)
+	request := httptest.NewRequest(http.MethodGet, "/health/ws", nil).WithContext(reqCtx)
+	go ha.WebSocketHandler(upgrade).ServeHTTP(httptest.NewRecorder(), request)
+
+	snapshot := conn.next(t)
+	if snapshot.Type != "snapshot" || snapshot.Status != StatusUp || len(snapshot.Checks) != 2 {
+		t.Errorf("Expected an UP snapshot of both checks, got %+v", snapshot)
+	}
+
+	ha.UpdateHealth(db, nil, errors.New("connection refused"))
+	update := conn.next(t)
+	if update.Type != "update" || update.Status != StatusDown || len(update.Checks) != 1 ||
+		update.Checks[0].Name != "db" || update.Checks[0].Status != StatusDown {
+		t.Errorf("Expected a DOWN update of db, got %+v", update)
+	}
+
+	// Disconnecting ends the stream and unsubscribes
+	cancel()
+	select {
+	case <-conn.closed:
+	case <-time.After(time.Second):
+		t.Fatal("Expected connection to be closed when the request context is done")
+	}
+	time.Sleep(10 * time.Millisecond)
+	ha.subsMu.Lock()
+	defer ha.subsMu.Unlock()
+	if len(ha.subscribers) != 0 {
+		t.Errorf("Expected subscriber to be removed, got %d", len(ha.subscribers))
+	}
+}
+
+func TestWebSocketHandlerResyncsAfterOverflow(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	ha.RegisterHealthCheck(&mockHealthChecker{name: "db"}, PriorityCritical)
+
+	sub := ha.subscribe()
+	defer ha.unsubscribe(sub)
+	for i := 0; i <= subscriberBuffer; i++ {
+		ha.notifySubscribers("db")
+	}
+	if !sub.overflowed.Load() {
+		t.Error("Expected a lagging subscriber to be marked for a resync")
+	}
+}
+
+func TestWebSocketHandlerClientClose(t *testing.T) {
+	ctx := context.Background()
+	ha := NewHealthAggregator(ctx)
+	ha.Start()
+	defer ha.Stop()
+
+	conn := newFakeWebSocket()
+	upgrade := func(w http.ResponseWriter, r *http.Request) (WebSocketConn, error) {
+		return conn, nil
+	}
+	go ha.WebSocketHandler(upgrade).ServeHTTP(httptest.NewRecorder(),
+		httptest.NewRequest(http.MethodGet, "/health/ws", nil))
+	conn.next(t)
+
+	// An idle client going away is noticed without a pending update or request cancellation
+	conn.reads <- errors.New("websocket: close 1001 (going away)")
+	select {
+	case <-conn.closed:
+	case <-time.After(time.Second):
+		t.Fatal("Expected connection to be closed when a read fails")
+	}
+	time.Sleep(10 * time.Millisecond)
+	ha.subsMu.Lock()
+	defer ha.subsMu.Unlock()
+	if len(ha.subscribers) != 0 {
+		t.Errorf("Expected subscriber to be removed, got %d", len(ha.subscribers))
+	}
+}